@@ -0,0 +1,67 @@
+// Package aes implements the Rijndael (AES) cipher as specified in FIPS-197.
+package aes
+
+import (
+	"crypto/cipher"
+	"strconv"
+)
+
+const (
+	// The AES block size in bytes.
+	BlockSize = 16
+
+	// The supported key sizes in bytes.
+	KeySize128 = 16
+	KeySize192 = 24
+	KeySize256 = 32
+)
+
+type KeySizeError int
+
+func (k KeySizeError) Error() string {
+	return "cryptster/aes: invalid key size " + strconv.Itoa(int(k))
+}
+
+// aesCipher is an instance of AES encryption.
+type aesCipher struct {
+	rounds    int
+	roundKeys [][4]byte
+}
+
+// NewCipher creates and returns a new cipher.Block.
+// The key must be 16, 24 or 32 bytes long to select
+// AES-128, AES-192 or AES-256.
+func NewCipher(key []byte) (cipher.Block, error) {
+	switch len(key) {
+	case KeySize128, KeySize192, KeySize256:
+	default:
+		return nil, KeySizeError(len(key))
+	}
+
+	c := new(aesCipher)
+	c.rounds = rounds(len(key))
+	c.roundKeys = keyExpansion(key)
+	return c, nil
+}
+
+func (c *aesCipher) BlockSize() int { return BlockSize }
+
+func (c *aesCipher) Encrypt(dst, src []byte) {
+	if len(src) < BlockSize {
+		panic("cryptster/aes: input not full block")
+	}
+	if len(dst) < BlockSize {
+		panic("cryptster/aes: output not full block")
+	}
+	putBlock(dst, encryptBlock(c.roundKeys, c.rounds, getBlock(src)))
+}
+
+func (c *aesCipher) Decrypt(dst, src []byte) {
+	if len(src) < BlockSize {
+		panic("cryptster/aes: input not full block")
+	}
+	if len(dst) < BlockSize {
+		panic("cryptster/aes: output not full block")
+	}
+	putBlock(dst, decryptBlock(c.roundKeys, c.rounds, getBlock(src)))
+}
@@ -0,0 +1,235 @@
+package aes
+
+// The state is kept as four 4-byte columns, state[c][r] holds the
+// byte at row r and column c, input byte n goes to state[n/4][n%4].
+
+// Obtain the number of rounds (Nr) for a key of the given size in bytes,
+// which is Nk + 6 where Nk is the number of 32-bit words in the key.
+func rounds(keySize int) int {
+	return keySize/4 + 6
+}
+
+// Encrypt one state using the expanded round keys.
+func encryptBlock(roundKeys [][4]byte, rounds int, state [4][4]byte) [4][4]byte {
+	state = addRoundKey(state, roundKeys, 0)
+
+	for round := 1; round < rounds; round++ {
+		state = subBytes(state)
+		state = shiftRows(state)
+		state = mixColumns(state)
+		state = addRoundKey(state, roundKeys, round)
+	}
+
+	// The final round has no MixColumns
+	state = subBytes(state)
+	state = shiftRows(state)
+	return addRoundKey(state, roundKeys, rounds)
+}
+
+// Decrypt one state using the expanded round keys; this is the
+// inverse cipher with the round keys applied in reverse order.
+func decryptBlock(roundKeys [][4]byte, rounds int, state [4][4]byte) [4][4]byte {
+	state = addRoundKey(state, roundKeys, rounds)
+
+	for round := rounds - 1; round > 0; round-- {
+		state = invShiftRows(state)
+		state = invSubBytes(state)
+		state = addRoundKey(state, roundKeys, round)
+		state = invMixColumns(state)
+	}
+
+	state = invShiftRows(state)
+	state = invSubBytes(state)
+	return addRoundKey(state, roundKeys, 0)
+}
+
+// Round keys are derived from the cipher key using Rijndael's key schedule.
+// AES requires a separate 128-bit round key block for each round plus one more,
+// the key must be 16, 24 or 32 bytes long.
+func keyExpansion(cipherkey []byte) [][4]byte {
+	nk := len(cipherkey) / 4
+	keys := make([][4]byte, (rounds(len(cipherkey))+1)*4)
+
+	// Copy the first Nk words
+	for i := 0; i < nk; i++ {
+		copy(keys[i][:], cipherkey[i*4:i*4+4])
+	}
+
+	for i := nk; i < len(keys); i++ {
+		if i%nk == 0 {
+			keys[i] = keyScheduleCore(keys[i-1], keys[i-nk], i/nk)
+		} else {
+			var ki [4]byte
+			for b := 0; b < 4; b++ {
+				ki[b] = keys[i-1][b]
+				// AES-256 applies an extra SubWord halfway through the key
+				if nk > 6 && i%nk == 4 {
+					ki[b] = subByte(ki[b])
+				}
+				ki[b] ^= keys[i-nk][b]
+			}
+			keys[i] = ki
+		}
+	}
+	return keys
+}
+
+// Calculates the i-nth word of the keys
+// w1 is the i-1 nth key word and wk is the i-Nk nth key word
+func keyScheduleCore(w1, wk [4]byte, round int) [4]byte {
+	var bs [4]byte
+
+	// RotWord and SubWord
+	for i := 0; i < 4; i++ {
+		bs[i] = subByte(w1[(i+1)%4])
+	}
+
+	for i, wb := range wk {
+		bs[i] ^= wb
+	}
+	bs[0] ^= rCON[round]
+
+	return bs
+}
+
+// Obtain the subbyte S-Box value for the given byte
+func subByte(b byte) byte {
+	return sBOX[b>>4][b&0x0f]
+}
+
+// Obtain the inverse S-Box value for the given byte
+func invSubByte(b byte) byte {
+	return invSBOX[b>>4][b&0x0f]
+}
+
+// The Rijndael S-box is a matrix (square array of numbers) used in the Rijndael cipher,
+// which the Advanced Encryption Standard (AES) cryptographic algorithm was based on.[1]
+// The S-box (substitution box) serves as a lookup table.
+func subBytes(state [4][4]byte) [4][4]byte {
+	var s [4][4]byte
+	for j, word := range state {
+		for i := 0; i < 4; i++ {
+			s[j][i] = subByte(word[i])
+		}
+	}
+	return s
+}
+
+// Substitute every byte of the state with the inverse S-box
+func invSubBytes(state [4][4]byte) [4][4]byte {
+	var s [4][4]byte
+	for j, word := range state {
+		for i := 0; i < 4; i++ {
+			s[j][i] = invSubByte(word[i])
+		}
+	}
+	return s
+}
+
+// Obtain a block from the given data
+func getBlock(data []byte) [4][4]byte {
+	var block [4][4]byte
+	l := len(data)
+	for j := 0; j < 4; j++ {
+		for i := 0; i < 4; i++ {
+			idx := j*4 + i
+			if idx < l {
+				block[j][i] = data[idx]
+			} else {
+				block[j][i] = 0x00
+			}
+		}
+	}
+	return block
+}
+
+// Copy the block into the given data
+func putBlock(data []byte, block [4][4]byte) {
+	for j := 0; j < 4; j++ {
+		copy(data[j*4:j*4+4], block[j][:])
+	}
+}
+
+func xorBlocks(a, b [4][4]byte) [4][4]byte {
+	var x [4][4]byte
+	for j := 0; j < 4; j++ {
+		for i := 0; i < 4; i++ {
+			x[j][i] = a[j][i] ^ b[j][i]
+		}
+	}
+
+	return x
+}
+
+// XOR the state with the round key of the given round
+func addRoundKey(state [4][4]byte, roundKeys [][4]byte, round int) [4][4]byte {
+	var key [4][4]byte
+	copy(key[:], roundKeys[round*4:round*4+4])
+	return xorBlocks(state, key)
+}
+
+// Perform the shift rows operation, row r is
+// cyclically shifted r bytes to the left
+func shiftRows(block [4][4]byte) [4][4]byte {
+	var s [4][4]byte
+	for c := 0; c < 4; c++ {
+		for r := 0; r < 4; r++ {
+			s[c][r] = block[(c+r)%4][r]
+		}
+	}
+	return s
+}
+
+// Perform the inverse shift rows operation, row r is
+// cyclically shifted r bytes to the right
+func invShiftRows(block [4][4]byte) [4][4]byte {
+	var s [4][4]byte
+	for c := 0; c < 4; c++ {
+		for r := 0; r < 4; r++ {
+			s[(c+r)%4][r] = block[c][r]
+		}
+	}
+	return s
+}
+
+// Multiply each column by the fixed polynomial a(x)
+func mixColumns(block [4][4]byte) [4][4]byte {
+	return multiplyColumns(block, &GALOIS)
+}
+
+// Multiply each column by the inverse polynomial a^-1(x)
+func invMixColumns(block [4][4]byte) [4][4]byte {
+	return multiplyColumns(block, &INV_GALOIS)
+}
+
+// Multiply every column of the block by the given matrix in GF(2^8)
+func multiplyColumns(block [4][4]byte, matrix *[4][4]byte) [4][4]byte {
+	var m [4][4]byte
+	for c := 0; c < 4; c++ {
+		for r := 0; r < 4; r++ {
+			for k := 0; k < 4; k++ {
+				m[c][r] ^= gmul(matrix[r][k], block[c][k])
+			}
+		}
+	}
+
+	return m
+}
+
+// Multiply two bytes as elements of GF(2^8) modulo
+// the polynomial x^8 + x^4 + x^3 + x + 1
+func gmul(a, b byte) byte {
+	var p byte
+	for b != 0 {
+		if b&1 != 0 {
+			p ^= a
+		}
+		if a&0x80 != 0 {
+			a = (a << 1) ^ 0x1b
+		} else {
+			a <<= 1
+		}
+		b >>= 1
+	}
+	return p
+}
@@ -0,0 +1,120 @@
+package aes
+
+import (
+	"encoding/hex"
+	"math/rand"
+	"testing"
+	"time"
+)
+
+// Generate a random byte
+func randByte() byte {
+	rand.Seed(time.Now().UTC().UnixNano())
+	return byte(rand.Int())
+}
+
+func TestAES(t *testing.T) {
+	var (
+		b   byte = 0xAF
+		sbx byte = 0x79
+
+		//s   string = "A"
+		//key string = "secret"
+	)
+
+	sb := subByte(b)
+	if sb != sbx {
+		t.Errorf("Incorrect subByte for 0x%x, expected %x got %x", b, sbx, sb)
+	}
+}
+
+func TestGmul(t *testing.T) {
+	// FIPS-197 section 4.2
+	if p := gmul(0x57, 0x83); p != 0xc1 {
+		t.Errorf("Incorrect {57} * {83}, expected c1 got %x", p)
+	}
+	if p := gmul(0x57, 0x13); p != 0xfe {
+		t.Errorf("Incorrect {57} * {13}, expected fe got %x", p)
+	}
+}
+
+// FIPS-197 Appendix A, the last word of each expanded key
+var keyExpansionVectors = []struct {
+	key, last string
+}{
+	{"2b7e151628aed2a6abf7158809cf4f3c", "b6630ca6"},
+	{"8e73b0f7da0e6452c810f32b809079e562f8ead2522c6b7b", "01002202"},
+	{"603deb1015ca71be2b73aef0857d77811f352c073b6108d72d9810a30914dff4", "706c631e"},
+}
+
+func TestKeyExpansion(t *testing.T) {
+	for _, v := range keyExpansionVectors {
+		k, _ := hex.DecodeString(v.key)
+		roundKeys := keyExpansion(k)
+
+		if len(roundKeys) != (rounds(len(k))+1)*4 {
+			t.Errorf("Incorrect number of round key words for key %s, got %d", v.key, len(roundKeys))
+		}
+
+		w := roundKeys[len(roundKeys)-1]
+		if hex.EncodeToString(w[:]) != v.last {
+			t.Errorf("Incorrect last round key word for key %s, expected %s got %x", v.key, v.last, w)
+		}
+	}
+}
+
+// FIPS-197 Appendix B and C vectors
+var aesVectors = []struct {
+	key, plaintext, ciphertext string
+}{
+	{
+		"2b7e151628aed2a6abf7158809cf4f3c",
+		"3243f6a8885a308d313198a2e0370734",
+		"3925841d02dc09fbdc118597196a0b32",
+	},
+	{
+		"000102030405060708090a0b0c0d0e0f",
+		"00112233445566778899aabbccddeeff",
+		"69c4e0d86a7b0430d8cdb78070b4c55a",
+	},
+	{
+		"000102030405060708090a0b0c0d0e0f1011121314151617",
+		"00112233445566778899aabbccddeeff",
+		"dda97ca4864cdfe06eaf70a0ec0d7191",
+	},
+	{
+		"000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f",
+		"00112233445566778899aabbccddeeff",
+		"8ea2b7ca516745bfeafc49904b496089",
+	},
+}
+
+func TestAESBlock(t *testing.T) {
+	for _, v := range aesVectors {
+		key, _ := hex.DecodeString(v.key)
+		plaintext, _ := hex.DecodeString(v.plaintext)
+
+		c, err := NewCipher(key)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		dst := make([]byte, BlockSize)
+		c.Encrypt(dst, plaintext)
+		if hex.EncodeToString(dst) != v.ciphertext {
+			t.Errorf("Incorrect encryption with key %s, expected %s got %x", v.key, v.ciphertext, dst)
+		}
+
+		c.Decrypt(dst, dst)
+		if hex.EncodeToString(dst) != v.plaintext {
+			t.Errorf("Incorrect decryption with key %s, expected %s got %x", v.key, v.plaintext, dst)
+		}
+	}
+}
+
+func TestKeySize(t *testing.T) {
+	_, err := NewCipher([]byte("short"))
+	if err != KeySizeError(5) {
+		t.Errorf("Expected KeySizeError(5), got %v", err)
+	}
+}
@@ -1,8 +1,10 @@
-package main
+// Package byteutil contains the bit and byte helpers shared by the
+// cryptster primitives.
+package byteutil
 
 // Determine if the given int is contained within
 // the specified range
-func between(x, a, b int) bool {
+func Between(x, a, b int) bool {
 	return a <= x && x <= b
 }
 
@@ -12,12 +14,24 @@ func Lrot64(x uint64, n uint64) uint64 {
 	return (x << n) | (x >> (64 - n))
 }
 
-// Perform a left bitwise rotation of
+// Perform a right bitwise rotation of
 // an 64 bit integer
+func Rrot64(x uint64, n uint64) uint64 {
+	return (x >> n) | (x << (64 - n))
+}
+
+// Perform a left bitwise rotation of
+// an 32 bit integer
 func Lrot32(x, n uint32) uint32 {
 	return (x << n) | (x >> (32 - n))
 }
 
+// Perform a right bitwise rotation of
+// an 32 bit integer
+func Rrot32(x, n uint32) uint32 {
+	return (x >> n) | (x << (32 - n))
+}
+
 // Obtain the bytes of a uint64 number (Big-Endian)
 func GetBytes32(x uint32) []byte {
 	return []byte{
@@ -52,3 +66,14 @@ func GetInt32(b []byte) uint32 {
 
 	return i32
 }
+
+// Obtain a int64 from the given byte slice
+func GetInt64(b []byte) uint64 {
+	i64 := uint64(b[0])
+	for i := 1; i < 8; i++ {
+		i64 <<= 8
+		i64 = i64 | uint64(b[i])
+	}
+
+	return i64
+}
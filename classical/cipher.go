@@ -1,4 +1,6 @@
-package main
+// Package classical implements simple byte-wise substitution and
+// transposition ciphers.
+package classical
 
 const (
 	CLASS_TRANSPOSITION = "transposition"
@@ -47,18 +49,18 @@ func (p PlainTextCipher) SetPlaintext(plaintext []byte) {
 // The rot cipher adds it's rotation to the encoded symbol
 // and subtracts it to the decoded ciphertext
 type ROTCipher struct {
-	rotation byte
+	Rotation byte
 }
 
 // Simple rotate function to implement the substitution
 // It rotates the byte by it's rotation field
 func (c ROTCipher) Encode(symbol byte) byte {
-	return symbol + c.rotation
+	return symbol + c.Rotation
 }
 
 // Simple decoding function for the ROT cipher
 func (c ROTCipher) Decode(ciphertext byte) byte {
-	return ciphertext - c.rotation
+	return ciphertext - c.Rotation
 }
 
 func (c ROTCipher) Class() string {
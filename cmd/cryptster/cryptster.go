@@ -0,0 +1,646 @@
+package main
+
+import (
+	"bytes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/Triztian/cryptster"
+	"github.com/Triztian/cryptster/aes"
+	"github.com/Triztian/cryptster/classical"
+	"github.com/Triztian/cryptster/des"
+	"github.com/Triztian/cryptster/rsa"
+)
+
+// The block ciphers accepted by the -c flag mapped to the mode
+// they use when the -m flag is not given.
+var blockCiphers = map[string]string{
+	"AES":       cryptster.ModeCBC,
+	"AESCBC128": cryptster.ModeCBC,
+	"AESCBC192": cryptster.ModeCBC,
+	"AESCBC256": cryptster.ModeCBC,
+	"AESCTR":    cryptster.ModeCTR,
+	"DES":       cryptster.ModeCBC,
+	"DES3":      cryptster.ModeCBC,
+	"DESX":      cryptster.ModeCBC,
+}
+
+// The AES cipher names accepted by the -c flag mapped to the
+// key size they require; a zero size is taken from the key itself.
+var aesKeySizes = map[string]int{
+	"AES":       0,
+	"AESCBC128": 16,
+	"AESCBC192": 24,
+	"AESCBC256": 32,
+	"AESCTR":    0,
+	"AESGCM":    0,
+}
+
+// The commands accepted as the first argument mapped to the function
+// that runs them with the rest of the arguments.
+var commands = map[string]func([]string) error{
+	"hash": runHash,
+	"kdf":  runKDF,
+}
+
+// This structure indicates the available
+// flags on the CLI
+type arguments struct {
+	Verbose *bool
+	Decode  *bool
+	Cipher  *string
+	File    *string
+	Text    *string
+	Output  *string
+	Key     *string
+	Hash    *bool
+	Genkey  *bool
+	Hex     *bool
+	AAD     *string
+	Mode    *string
+	HexKey  *bool
+	Keying  *int
+	Parity  *string
+	Algo    *string
+	Length  *int
+	Verify  *string
+	Pass    *string
+	Bits    *int
+	KeyFmt  *string
+	Padding *string
+}
+
+func main() {
+	var (
+		args   arguments
+		result []byte
+		err    error
+	)
+
+	// The commands have their own flags
+	if len(os.Args) > 1 {
+		if command, ok := commands[os.Args[1]]; ok {
+			err = command(os.Args[2:])
+			if err != nil {
+				fail(err)
+			}
+			return
+		}
+	}
+
+	// Initialize the flag/cli arguments variable
+	args = initFlags(flag.CommandLine)
+
+	flag.Parse()
+	// Print the arguments if Verbose was enabled
+	printArgs(&args)
+
+	if *args.Genkey {
+		err = genKey(&args)
+		if err != nil {
+			fail(err)
+		}
+		return
+	}
+
+	if *args.Verify != "" {
+		err = verify(&args)
+		if err != nil {
+			fmt.Println("FAILED")
+			fail(err)
+		}
+		fmt.Println("OK")
+		return
+	}
+
+	// Block ciphers write their output as the input is processed
+	if _, ok := blockCiphers[*args.Cipher]; ok && !*args.Hash {
+		err = runBlock(&args)
+		if err != nil {
+			fail(err)
+		}
+		return
+	}
+
+	result, err = run(&args)
+	if err != nil {
+		fail(err)
+	}
+
+	// If the Output flag is provided
+	// it is stored in the specified file and not printed to stdout
+	if *args.Output != "" {
+		err = output(result, *args.Output)
+		if err != nil {
+			fail(err)
+		}
+	} else {
+		if *args.Hex {
+			fmt.Println(hex.EncodeToString(result))
+		} else {
+			fmt.Println(toString(result))
+		}
+	}
+}
+
+// Perform the operation selected by the arguments and
+// return its result.
+func run(args *arguments) ([]byte, error) {
+	// The modes are only implemented for the block ciphers
+	if _, ok := blockCiphers[*args.Cipher]; *args.Mode != "" && !ok {
+		return nil, errors.New("cryptster: the -m flag is only supported by the block ciphers, not " + *args.Cipher)
+	}
+
+	reader, err := getReader(args)
+	if err != nil {
+		return nil, err
+	}
+
+	if *args.Hash {
+		warnInsecure(*args.Algo)
+		if *args.Key == "" {
+			return cryptster.HashLength(reader, *args.Algo, *args.Length)
+		}
+
+		// A keyed hash is a MAC
+		key, err := getKey(args)
+		if err != nil {
+			return nil, err
+		}
+		return cryptster.MACWith(reader, *args.Algo, key, *args.Length)
+
+	} else if *args.Cipher != "" {
+		if *args.Cipher == "AESGCM" {
+			header, key, err := getCipherKey(args, reader)
+			if err != nil {
+				return nil, err
+			}
+
+			result, err := cryptster.AESGCM(reader, key, []byte(*args.AAD), *args.Decode)
+			if err != nil || header == nil || *args.Decode {
+				return result, err
+			}
+
+			// The salt and iteration count precede the ciphertext
+			var buf bytes.Buffer
+			header.WriteTo(&buf)
+			return append(buf.Bytes(), result...), nil
+
+		} else if *args.Cipher == "RSA" {
+			key, err := getKey(args)
+			if err != nil {
+				return nil, err
+			}
+			return cryptster.RSA(reader, key, *args.Padding, *args.Decode)
+
+		} else {
+			return cryptster.CipherText(reader, getCipher(args), *args.Decode)
+		}
+	}
+
+	return nil, nil
+}
+
+// Verify the MAC given with the -verify flag against the
+// MAC of the input using the key and hash algorithm
+func verify(args *arguments) error {
+	if !*args.Hash {
+		return errors.New("cryptster: -verify requires the -h flag")
+	}
+
+	expected, err := hex.DecodeString(*args.Verify)
+	if err != nil {
+		return err
+	}
+
+	reader, err := getReader(args)
+	if err != nil {
+		return err
+	}
+
+	key, err := getKey(args)
+	if err != nil {
+		return err
+	}
+
+	warnInsecure(*args.Algo)
+	return cryptster.VerifyMAC(reader, *args.Algo, key, *args.Length, expected)
+}
+
+// Perform the block cipher selected by the arguments in the mode given
+// by the -m flag, the stream modes process the input in constant memory.
+// The result is written to the output file or stdout.
+func runBlock(args *arguments) error {
+	reader, err := getReader(args)
+	if err != nil {
+		return err
+	}
+
+	header, key, err := getCipherKey(args, reader)
+	if err != nil {
+		return err
+	}
+
+	block, err := getBlock(args, key)
+	if err != nil {
+		return err
+	}
+
+	blockMode := blockCiphers[*args.Cipher]
+	if *args.Mode != "" {
+		blockMode = strings.ToUpper(*args.Mode)
+	}
+	printLn("Mode: "+blockMode, *args.Verbose)
+
+	// ECB and CBC hold the whole result in memory, the output file is
+	// only written once it succeeded so a failed decryption does not
+	// truncate it
+	var (
+		writer   io.Writer = os.Stdout
+		buffered *bytes.Buffer
+	)
+	if *args.Output != "" {
+		if blockMode == cryptster.ModeECB || blockMode == cryptster.ModeCBC {
+			buffered = new(bytes.Buffer)
+			writer = buffered
+		} else {
+			f, err := os.Create(*args.Output)
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+			writer = f
+		}
+	}
+
+	if *args.Hex {
+		writer = hex.NewEncoder(writer)
+	}
+
+	// The salt and iteration count precede the ciphertext
+	if header != nil && !*args.Decode {
+		if _, err := header.WriteTo(writer); err != nil {
+			return err
+		}
+	}
+
+	err = cryptster.Crypt(writer, reader, block, blockMode, *args.Decode)
+	if err != nil {
+		return err
+	}
+
+	if buffered != nil {
+		return output(buffered.Bytes(), *args.Output)
+	}
+
+	// Only the hex output is terminated with a newline, the raw
+	// ciphertext must not be changed
+	if *args.Hex && *args.Output == "" {
+		fmt.Println()
+	}
+	return nil
+}
+
+// Obtain the block cipher selected by the -c flag
+func getBlock(args *arguments, key []byte) (cipher.Block, error) {
+	if *args.Cipher == "DES" || *args.Cipher == "DES3" {
+		parity, err := getParity(args)
+		if err != nil {
+			return nil, err
+		}
+
+		if *args.Cipher == "DES" {
+			return des.NewCipherParity(key, parity)
+		}
+
+		if *args.Keying != 0 && des.KeyingOption(len(key)) != *args.Keying {
+			return nil, fmt.Errorf("cryptster: DES3 keying option %d does not match a %d byte key", *args.Keying, len(key))
+		}
+		return des.NewTripleDESCipherParity(key, parity)
+
+	} else if *args.Cipher == "DESX" {
+		return des.NewDESXCipher(key)
+	}
+
+	size := aesKeySizes[*args.Cipher]
+	if size > 0 && len(key) != size {
+		return nil, fmt.Errorf("cryptster: %s requires a %d byte key, got %d", *args.Cipher, size, len(key))
+	}
+	return aes.NewCipher(key)
+}
+
+// Obtain the key of a symmetric cipher from either the -k or the -p flag,
+// the header is nil unless the key is derived from a passphrase
+func getCipherKey(args *arguments, reader io.Reader) (*cryptster.PassphraseHeader, []byte, error) {
+	if *args.Pass == "" {
+		key, err := getKey(args)
+		return nil, key, err
+	}
+
+	if *args.Key != "" {
+		return nil, nil, errors.New("cryptster: the -k and -p flags cannot be used together")
+	}
+	return getPassphraseKey(args, reader)
+}
+
+// Derive the key of the AES or DES3 cipher from the passphrase given by
+// the -p flag, when decrypting the PBKDF2 parameters are read from the
+// header that precedes the ciphertext
+func getPassphraseKey(args *arguments, reader io.Reader) (*cryptster.PassphraseHeader, []byte, error) {
+	var keyLen int
+
+	if size, ok := aesKeySizes[*args.Cipher]; ok {
+		keyLen = size
+		if keyLen == 0 {
+			keyLen = 32
+		}
+
+	} else if *args.Cipher == "DES3" {
+		switch *args.Keying {
+		case 0, des.KeyingOption1:
+			keyLen = 24
+		case des.KeyingOption2:
+			keyLen = 16
+		case des.KeyingOption3:
+			keyLen = 8
+		default:
+			return nil, nil, fmt.Errorf("cryptster: unknown DES3 keying option %d", *args.Keying)
+		}
+
+	} else {
+		return nil, nil, errors.New("cryptster: the -p flag is only supported by the AES and DES3 ciphers")
+	}
+
+	var (
+		header *cryptster.PassphraseHeader
+		err    error
+	)
+	if *args.Decode {
+		header, err = cryptster.ReadPassphraseHeader(reader)
+	} else {
+		header, err = cryptster.NewPassphraseHeader()
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	key := header.Key([]byte(*args.Pass), keyLen)
+	if *args.Cipher == "DES3" {
+		key = des.SetOddParity(key)
+	}
+	return header, key, nil
+}
+
+// Obtain the DES key parity policy given by the -parity flag
+func getParity(args *arguments) (des.Parity, error) {
+	switch strings.ToLower(*args.Parity) {
+	case "ignore":
+		return des.ParityIgnore, nil
+	case "enforce":
+		return des.ParityEnforce, nil
+	case "fix":
+		return des.ParityFix, nil
+	}
+	return des.ParityIgnore, errors.New("cryptster: unknown parity policy " + *args.Parity)
+}
+
+// Generate a key for the selected cipher and print it in hex,
+// or store it in the output file
+func genKey(args *arguments) error {
+	var (
+		key []byte
+		err error
+	)
+
+	if *args.Cipher == "DES" {
+		key, err = des.GenerateKey()
+
+	} else if *args.Cipher == "DES3" {
+		option := *args.Keying
+		if option == 0 {
+			option = des.KeyingOption1
+		}
+		key, err = des.GenerateTripleDESKey(option)
+
+	} else if *args.Cipher == "RSA" {
+		return genRSAKey(args)
+
+	} else {
+		return errors.New("cryptster: key generation is not supported for " + *args.Cipher)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if *args.Output != "" {
+		return output([]byte(hex.EncodeToString(key)), *args.Output)
+	}
+	fmt.Println(hex.EncodeToString(key))
+	return nil
+}
+
+// Generate an RSA key pair of the size given by -b, the private key is
+// stored as PEM in the output file and the public key in the same file
+// with the .pub extension
+func genRSAKey(args *arguments) error {
+	var format rsa.KeyFormat
+	switch strings.ToLower(*args.KeyFmt) {
+	case "pkcs8":
+		format = rsa.PKCS8
+	case "pkcs1":
+		format = rsa.PKCS1
+	default:
+		return errors.New("cryptster: unknown key format " + *args.KeyFmt)
+	}
+
+	priv, err := rsa.GenerateKey(rand.Reader, *args.Bits)
+	if err != nil {
+		return err
+	}
+
+	privPath := *args.Output
+	if privPath == "" {
+		privPath = "rsa_key"
+	}
+	pubPath := privPath + ".pub"
+
+	if err := ioutil.WriteFile(privPath, rsa.MarshalPrivateKey(priv, format), 0600); err != nil {
+		return err
+	}
+	if err := output(rsa.MarshalPublicKey(&priv.PublicKey, format), pubPath); err != nil {
+		return err
+	}
+
+	fmt.Println("Private key: " + privPath)
+	fmt.Println("Public key: " + pubPath)
+	return nil
+}
+
+// Obtain the reader from where the data will be read.
+// If the arguments specifias a file from where to read the data
+// it is used instead of the -t argument value.
+// Either way we return a io.Reader object whichs source is a file
+// or a string.
+func getReader(args *arguments) (io.Reader, error) {
+	if *args.Text != "" && *args.File == "" {
+		printLn("Data from -t flag", *args.Verbose)
+		return strings.NewReader(*args.Text), nil
+
+	} else if *args.File != "" {
+		printLn("Data from -f flag", *args.Verbose)
+		return os.Open(*args.File)
+
+	} else {
+		return nil, errors.New("cryptster: no input data, use the -t or -f flags")
+
+	}
+}
+
+// Obtain a cipher given the arguments.
+// Ciphers are mapped from a string to a "instance" of the
+// cipher. New ciphers and their CLI values are defined here
+func getCipher(args *arguments) classical.SimpleCipher {
+	printLn("CipherArg: "+*args.Cipher, *args.Verbose)
+	if *args.Cipher == "ROT13" {
+		return classical.ROTCipher{Rotation: 13}
+	} else if *args.Cipher == "ROUTE" {
+		return new(classical.RouteCipher)
+	} else {
+		if *args.Verbose {
+			fmt.Println("Plain Cipher")
+		}
+		return classical.PlainTextCipher{}
+	}
+}
+
+// Obtain the key bytes depending on the selected cipher.
+// The RSA key is read from the file given by the -k flag,
+// the symmetric ciphers use the -k string itself.
+func getKey(args *arguments) ([]byte, error) {
+	if *args.Key == "" {
+		return nil, errors.New("cryptster: key is missing, use the -k flag")
+	}
+
+	// The RSA key is the content of a key file
+	if *args.Cipher == "RSA" {
+		return ioutil.ReadFile(*args.Key)
+	}
+
+	if *args.Verbose {
+		fmt.Println("Using Key: ", *args.Key)
+	}
+
+	// The key is not truncated, HMAC accepts keys of any length
+	if *args.HexKey {
+		return hex.DecodeString(*args.Key)
+	}
+	return []byte(*args.Key), nil
+}
+
+// Initialize the flags that the available on the CLI
+func initFlags(flags *flag.FlagSet) arguments {
+	args := arguments{
+		flags.Bool("v", false, "Work in verbose mode."),
+		flags.Bool("d", false, "Decode the string or file content using the specified cipher."),
+		flags.String("c", "Plain", "The cipher that will be used to encode data: Plain, ROT13, ROUTE, AES, AESCBC128, AESCBC192, AESCBC256, AESCTR, AESGCM, DES, DES3, DESX, RSA"),
+		flags.String("f", "", "The file path from where the data will be read."),
+		flags.String("t", "", "The text to be ciphered/unciphered; as string"),
+		flags.String("o", "", "The file path to where the output will be stored."),
+		flags.String("k", "", "The key to use for the given cipher, with -h a MAC is computed"),
+		flags.Bool("h", false, "Indicates if a hash of the file or text will be computed, see -a"),
+		flags.Bool("g", false, "Indicates if a key or the key pairs will be generated for the cipher"),
+		flags.Bool("x", false, "Indicates if the output will be in hex format"),
+		flags.String("aad", "", "The additional authenticated data for the AESGCM cipher"),
+		flags.String("m", "", "The mode of the block ciphers: ECB, CBC, CFB, OFB, CTR"),
+		flags.Bool("khex", false, "Indicates if the key given with -k is hex encoded"),
+		flags.Int("ko", 0, "The DES3 keying option: 1 (24 byte key), 2 (16 byte key) or 3 (8 byte key); chosen by the key length if not given"),
+		flags.String("parity", "ignore", "The DES key parity policy: ignore, enforce or fix"),
+		flags.String("a", "sha1", "The hash algorithm used with -h: "+strings.Join(cryptster.HashAlgorithms(), ", ")),
+		flags.Int("l", 0, "The output length in bytes of the shake128, shake256, blake2b and blake2s hashes"),
+		flags.String("verify", "", "The hex encoded MAC to verify, used with -h and -k"),
+		flags.String("p", "", "The passphrase from which the AES or DES3 key is derived with PBKDF2, used instead of -k"),
+		flags.Int("b", 2048, "The size in bits of the RSA keys generated with -g"),
+		flags.String("kf", "pkcs8", "The PEM format of the RSA keys generated with -g: pkcs8 or pkcs1"),
+		flags.String("pad", "oaep-sha256", "The RSA encryption padding: oaep-sha256, oaep-sha1 or pkcs1v15"),
+	}
+
+	return args
+}
+
+// Print the error and exit with a non-zero status
+func fail(err error) {
+	fmt.Fprintln(os.Stderr, err)
+	os.Exit(1)
+}
+
+// Warn on stderr when the hash algorithm is insecure, the warning
+// does not mix with the digests printed to stdout
+func warnInsecure(algorithm string) {
+	if cryptster.IsInsecureHash(algorithm) {
+		fmt.Fprintf(os.Stderr, "cryptster: WARNING: %s is insecure, use it only to interoperate with legacy systems\n", algorithm)
+	}
+}
+
+// Print a line, based on the value of the verbose flag
+func printLn(message string, verbose bool) {
+	if verbose {
+		fmt.Println(message)
+	}
+}
+
+// Print the arguments of the program
+func printArgs(args *arguments) {
+	if *args.Verbose {
+		fmt.Println("Verbose: ", *args.Verbose)
+		fmt.Println("Decode: ", *args.Decode)
+		fmt.Println("Cipher: ", *args.Cipher)
+		fmt.Println("Text: ", *args.Text)
+		fmt.Println("Hash: ", *args.Hash)
+		fmt.Println("File: ", *args.File)
+		fmt.Println("Output: ", *args.Output)
+		fmt.Println("Key: ", *args.Key)
+		fmt.Println("Genkey: ", *args.Genkey)
+		fmt.Println("AAD: ", *args.AAD)
+		fmt.Println("Mode: ", *args.Mode)
+		fmt.Println("HexKey: ", *args.HexKey)
+		fmt.Println("Keying: ", *args.Keying)
+		fmt.Println("Parity: ", *args.Parity)
+		fmt.Println("Algorithm: ", *args.Algo)
+		fmt.Println("Length: ", *args.Length)
+		fmt.Println("Verify: ", *args.Verify)
+		fmt.Println("Passphrase: ", *args.Pass != "")
+		fmt.Println("Bits: ", *args.Bits)
+		fmt.Println("Key format: ", *args.KeyFmt)
+		fmt.Println("Padding: ", *args.Padding)
+	}
+}
+
+// Code and String representation of a byte
+func strByte(b byte) string {
+	return fmt.Sprintf("%d:%c", b, b)
+}
+
+func toString(data []byte) string {
+	// Concatenate the character representation of the
+	// processed symbol
+	text := ""
+	for _, symbol := range data {
+		text += fmt.Sprintf("%c", symbol)
+	}
+
+	return text
+}
+
+// Write the data into the file at the given path
+func output(data []byte, filepath string) error {
+	return ioutil.WriteFile(filepath, data, 0644)
+}
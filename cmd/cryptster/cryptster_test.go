@@ -0,0 +1,132 @@
+package main
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"flag"
+	"io/ioutil"
+	"strings"
+	"testing"
+)
+
+// Parse the command line arguments like main does
+func parseArgs(t *testing.T, arguments ...string) *arguments {
+	flags := flag.NewFlagSet("cryptster", flag.ContinueOnError)
+	args := initFlags(flags)
+	if err := flags.Parse(arguments); err != nil {
+		t.Fatal(err)
+	}
+	return &args
+}
+
+func TestRunBlockOutput(t *testing.T) {
+	defer checksumDir(t)()
+
+	// A failed decryption leaves the output file untouched
+	var failures = [][]string{
+		{"-d", "-c", "AES", "-k", "0123456789abcdef", "-t", "short", "-o", "abc.txt"},
+		{"-d", "-c", "AES", "-m", "ECB", "-k", "0123456789abcdef", "-t", "short", "-o", "abc.txt"},
+		{"-d", "-c", "AES", "-p", "passphrase", "-t", "short", "-o", "abc.txt"},
+	}
+
+	for _, v := range failures {
+		if err := runBlock(parseArgs(t, v...)); err == nil {
+			t.Errorf("Expected an error for %q", v)
+		}
+		if data, _ := ioutil.ReadFile("abc.txt"); string(data) != "abc" {
+			t.Errorf("Incorrect output file after %q, expected %q got %q", v, "abc", data)
+		}
+	}
+
+	for _, blockMode := range []string{"ECB", "CBC", "CTR"} {
+		err := runBlock(parseArgs(t, "-c", "AES", "-m", blockMode, "-k", "0123456789abcdef", "-f", "abc.txt", "-o", "abc.enc"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = runBlock(parseArgs(t, "-d", "-c", "AES", "-m", blockMode, "-k", "0123456789abcdef", "-f", "abc.enc", "-o", "abc.dec"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if data, _ := ioutil.ReadFile("abc.dec"); string(data) != "abc" {
+			t.Errorf("Incorrect %s decryption, expected %q got %q", blockMode, "abc", data)
+		}
+	}
+}
+
+func TestRunMode(t *testing.T) {
+	for _, cipher := range []string{"AESGCM", "RSA", "ROT13", "Plain"} {
+		_, err := run(parseArgs(t, "-c", cipher, "-m", "CTR", "-k", "0123456789abcdef", "-t", "hi"))
+		if err == nil {
+			t.Errorf("Expected an error for the -m flag with %s", cipher)
+		}
+	}
+
+	if _, err := run(parseArgs(t, "-c", "ROT13", "-t", "hi")); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestLongMACKey(t *testing.T) {
+	// Longer than the 512 bytes that were read from -k
+	key := strings.Repeat("k", 600)
+	mac := hmac.New(sha256.New, []byte(key))
+	mac.Write([]byte("abc"))
+	expected := mac.Sum(nil)
+
+	result, err := run(parseArgs(t, "-h", "-a", "sha256", "-k", key, "-t", "abc"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(result, expected) {
+		t.Errorf("Incorrect MAC of a 600 byte key, expected %x got %x", expected, result)
+	}
+
+	hexKey := hex.EncodeToString([]byte(key))
+	result, err = run(parseArgs(t, "-h", "-a", "sha256", "-khex", "-k", hexKey, "-t", "abc"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(result, expected) {
+		t.Errorf("Incorrect MAC of a 600 byte hex key, expected %x got %x", expected, result)
+	}
+
+	err = verify(parseArgs(t, "-h", "-a", "sha256", "-k", key, "-t", "abc", "-verify", hex.EncodeToString(expected)))
+	if err != nil {
+		t.Errorf("Incorrect verification of the MAC of a 600 byte key, %v", err)
+	}
+}
+
+func TestPassphraseFlags(t *testing.T) {
+	defer checksumDir(t)()
+
+	err := runBlock(parseArgs(t, "-c", "AES", "-k", "0123456789abcdef", "-p", "passphrase", "-t", "abc"))
+	if err == nil {
+		t.Error("Expected an error for the -k and -p flags together")
+	}
+	_, err = run(parseArgs(t, "-c", "AESGCM", "-k", "0123456789abcdef", "-p", "passphrase", "-t", "abc"))
+	if err == nil {
+		t.Error("Expected an error for the -k and -p flags together")
+	}
+
+	ciphertext, err := run(parseArgs(t, "-c", "AESGCM", "-p", "passphrase", "-aad", "data", "-t", "abc"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile("abc.gcm", ciphertext, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	plaintext, err := run(parseArgs(t, "-d", "-c", "AESGCM", "-p", "passphrase", "-aad", "data", "-f", "abc.gcm"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(plaintext) != "abc" {
+		t.Errorf("Incorrect AESGCM passphrase decryption, expected %q got %q", "abc", plaintext)
+	}
+
+	if _, err := run(parseArgs(t, "-d", "-c", "AESGCM", "-p", "wrong", "-aad", "data", "-f", "abc.gcm")); err == nil {
+		t.Error("Expected an error for the wrong passphrase")
+	}
+}
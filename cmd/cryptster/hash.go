@@ -0,0 +1,139 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/hex"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/Triztian/cryptster"
+)
+
+// Hash the files given as arguments, or the standard input if there are
+// none, and print a "<hex>  <filename>" line for each one like sha1sum.
+// With -check the files listed in a manifest of such lines are verified.
+func runHash(arguments []string) error {
+	flags := flag.NewFlagSet("hash", flag.ExitOnError)
+	algo := flags.String("a", "sha1", "The hash algorithm: "+strings.Join(cryptster.HashAlgorithms(), ", "))
+	length := flags.Int("l", 0, "The output length in bytes of the shake128, shake256, blake2b and blake2s hashes")
+	check := flags.String("check", "", "The manifest of \"<hex>  <filename>\" lines to verify")
+	flags.Parse(arguments)
+
+	warnInsecure(*algo)
+	if *check != "" {
+		return checkManifest(os.Stdout, *check, *algo, *length)
+	}
+
+	files := flags.Args()
+	if len(files) == 0 {
+		files = []string{"-"}
+	}
+	return hashFiles(os.Stdout, files, *algo, *length)
+}
+
+// Print the "<hex>  <filename>" line of every file, like sha1sum the files
+// that cannot be read are reported on stderr and the rest are still hashed
+func hashFiles(w io.Writer, files []string, algorithm string, length int) error {
+	var unreadable int
+
+	for _, name := range files {
+		digest, err := hashFile(name, algorithm, length)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "cryptster:", err)
+			unreadable++
+			continue
+		}
+		fmt.Fprintf(w, "%s  %s\n", hex.EncodeToString(digest), name)
+	}
+
+	if unreadable > 0 {
+		return fmt.Errorf("cryptster: %d file(s) could not be read", unreadable)
+	}
+	return nil
+}
+
+// Verify every file listed in the manifest, an OK or FAILED line is
+// printed for each one and an error is returned if any did not match
+func checkManifest(w io.Writer, manifest, algorithm string, length int) error {
+	f, err := os.Open(manifest)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	var failed, malformed int
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if line == "" {
+			continue
+		}
+
+		expected, name, ok := parseChecksumLine(line)
+		if !ok {
+			malformed++
+			continue
+		}
+
+		digest, err := hashFile(name, algorithm, length)
+		if err != nil {
+			fmt.Fprintf(w, "%s: FAILED open or read\n", name)
+			failed++
+		} else if !bytes.Equal(digest, expected) {
+			fmt.Fprintf(w, "%s: FAILED\n", name)
+			failed++
+		} else {
+			fmt.Fprintf(w, "%s: OK\n", name)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+
+	if malformed > 0 {
+		fmt.Fprintf(os.Stderr, "cryptster: WARNING: %d line(s) improperly formatted\n", malformed)
+	}
+	if failed > 0 {
+		return fmt.Errorf("cryptster: WARNING: %d computed checksum(s) did NOT match", failed)
+	}
+	if malformed > 0 {
+		return fmt.Errorf("cryptster: %s has improperly formatted lines", manifest)
+	}
+	return nil
+}
+
+// Obtain the digest and filename of a "<hex>  <filename>" line, a '*'
+// before the filename marks binary mode in sha1sum and is skipped
+func parseChecksumLine(line string) ([]byte, string, bool) {
+	i := strings.Index(line, " ")
+	if i <= 0 || i+2 >= len(line) || (line[i+1] != ' ' && line[i+1] != '*') {
+		return nil, "", false
+	}
+
+	digest, err := hex.DecodeString(line[:i])
+	if err != nil {
+		return nil, "", false
+	}
+	return digest, line[i+2:], true
+}
+
+// Hash the content of the file, "-" is the standard input
+func hashFile(name, algorithm string, length int) ([]byte, error) {
+	var reader io.Reader = os.Stdin
+
+	if name != "-" {
+		f, err := os.Open(name)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		reader = f
+	}
+
+	return cryptster.HashLength(reader, algorithm, length)
+}
@@ -0,0 +1,156 @@
+package main
+
+import (
+	"bytes"
+	"encoding/hex"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// The SHA-1 digests of "abc" and "" in hex
+const (
+	sha1ABC   = "a9993e364706816aba3e25717850c26c9cd0d89d"
+	sha1Empty = "da39a3ee5e6b4b0d3255bfef95601890afd80709"
+)
+
+func TestParseChecksumLine(t *testing.T) {
+	var lines = []struct {
+		line   string
+		digest string
+		name   string
+		ok     bool
+	}{
+		{sha1ABC + "  file.txt", sha1ABC, "file.txt", true},
+		// The binary mode marker of sha1sum
+		{sha1ABC + " *file.bin", sha1ABC, "file.bin", true},
+		// Spaces in the filename are kept
+		{sha1ABC + "  my file.txt", sha1ABC, "my file.txt", true},
+		{sha1ABC + "   file.txt", sha1ABC, " file.txt", true},
+		// Malformed lines
+		{sha1ABC + " file.txt", "", "", false},
+		{sha1ABC + "  ", "", "", false},
+		{sha1ABC, "", "", false},
+		{"  file.txt", "", "", false},
+		{"xyz  file.txt", "", "", false},
+		{"abc  file.txt", "", "", false},
+	}
+
+	for _, v := range lines {
+		digest, name, ok := parseChecksumLine(v.line)
+		if ok != v.ok {
+			t.Errorf("Incorrect parsing of %q, expected ok %v", v.line, v.ok)
+			continue
+		}
+		if hex.EncodeToString(digest) != v.digest || name != v.name {
+			t.Errorf("Incorrect parsing of %q, expected %s %q got %x %q", v.line, v.digest, v.name, digest, name)
+		}
+	}
+}
+
+// Create a directory with an "abc" and an empty file and change to it,
+// the returned function restores the working directory and removes it
+func checksumDir(t *testing.T) func() {
+	dir, err := ioutil.TempDir("", "cryptster")
+	if err != nil {
+		t.Fatal(err)
+	}
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ioutil.WriteFile(filepath.Join(dir, "abc.txt"), []byte("abc"), 0644)
+	ioutil.WriteFile(filepath.Join(dir, "empty.txt"), nil, 0644)
+	if err := os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+
+	return func() {
+		os.Chdir(wd)
+		os.RemoveAll(dir)
+	}
+}
+
+func TestCheckManifest(t *testing.T) {
+	defer checksumDir(t)()
+
+	var manifests = []struct {
+		manifest string
+		output   string
+		fail     bool
+	}{
+		{
+			sha1ABC + "  abc.txt\n" + sha1Empty + " *empty.txt\n",
+			"abc.txt: OK\nempty.txt: OK\n",
+			false,
+		},
+		// Windows line endings and blank lines are accepted
+		{
+			sha1ABC + "  abc.txt\r\n\r\n",
+			"abc.txt: OK\n",
+			false,
+		},
+		{
+			sha1Empty + "  abc.txt\n" + sha1Empty + "  empty.txt\n",
+			"abc.txt: FAILED\nempty.txt: OK\n",
+			true,
+		},
+		{
+			sha1ABC + "  missing.txt\n" + sha1ABC + "  abc.txt\n",
+			"missing.txt: FAILED open or read\nabc.txt: OK\n",
+			true,
+		},
+		// Malformed lines are skipped but make the check fail
+		{
+			"not a checksum line\n" + sha1ABC + "  abc.txt\n",
+			"abc.txt: OK\n",
+			true,
+		},
+	}
+
+	for i, v := range manifests {
+		if err := ioutil.WriteFile("manifest", []byte(v.manifest), 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		var out bytes.Buffer
+		err := checkManifest(&out, "manifest", "sha1", 0)
+		if out.String() != v.output {
+			t.Errorf("Incorrect output of manifest %d, expected %q got %q", i, v.output, out.String())
+		}
+		if (err != nil) != v.fail {
+			t.Errorf("Incorrect status of manifest %d, expected failure %v got %v", i, v.fail, err)
+		}
+	}
+
+	if err := checkManifest(&bytes.Buffer{}, "missing-manifest", "sha1", 0); err == nil {
+		t.Error("Expected an error for a missing manifest")
+	}
+}
+
+func TestHashFiles(t *testing.T) {
+	defer checksumDir(t)()
+
+	// The files after an unreadable one are still hashed
+	var out bytes.Buffer
+	err := hashFiles(&out, []string{"abc.txt", "missing.txt", "empty.txt"}, "sha1", 0)
+	if err == nil {
+		t.Error("Expected an error for a missing file")
+	}
+
+	expected := sha1ABC + "  abc.txt\n" + sha1Empty + "  empty.txt\n"
+	if out.String() != expected {
+		t.Errorf("Incorrect output, expected %q got %q", expected, out.String())
+	}
+
+	out.Reset()
+	if err := hashFiles(&out, []string{"abc.txt"}, "sha1", 0); err != nil {
+		t.Error(err)
+	}
+	if !strings.HasPrefix(out.String(), sha1ABC) {
+		t.Errorf("Incorrect output %q", out.String())
+	}
+}
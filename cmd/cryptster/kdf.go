@@ -0,0 +1,69 @@
+package main
+
+import (
+	"encoding/hex"
+	"errors"
+	"flag"
+	"fmt"
+	"strings"
+
+	"github.com/Triztian/cryptster"
+)
+
+// A flag that may be given several times, every value is kept
+type stringList []string
+
+func (l *stringList) String() string {
+	return strings.Join(*l, ",")
+}
+
+func (l *stringList) Set(value string) error {
+	*l = append(*l, value)
+	return nil
+}
+
+// Derive keys from the secret given with -k using HKDF, one key is
+// printed in hex for every -info flag
+func runKDF(arguments []string) error {
+	var info stringList
+
+	flags := flag.NewFlagSet("kdf", flag.ExitOnError)
+	algo := flags.String("a", "sha256", "The hash algorithm of HKDF: "+strings.Join(cryptster.HashAlgorithms(), ", "))
+	secret := flags.String("k", "", "The secret from which the keys are derived")
+	hexKey := flags.Bool("khex", false, "Indicates if the secret given with -k is hex encoded")
+	salt := flags.String("salt", "", "The hex encoded salt, a string of zeros if not given")
+	length := flags.Int("l", 32, "The length in bytes of every derived key")
+	flags.Var(&info, "info", "The context of a derived key, give it once for every key")
+	flags.Parse(arguments)
+	warnInsecure(*algo)
+
+	if *secret == "" {
+		return errors.New("cryptster: secret is missing, use the -k flag")
+	}
+
+	ikm := []byte(*secret)
+	if *hexKey {
+		var err error
+		if ikm, err = hex.DecodeString(*secret); err != nil {
+			return err
+		}
+	}
+
+	saltBytes, err := hex.DecodeString(*salt)
+	if err != nil {
+		return err
+	}
+
+	if len(info) == 0 {
+		info = stringList{""}
+	}
+
+	for _, context := range info {
+		key, err := cryptster.DeriveKey(*algo, ikm, saltBytes, []byte(context), *length)
+		if err != nil {
+			return err
+		}
+		fmt.Println(hex.EncodeToString(key))
+	}
+	return nil
+}
@@ -1,15 +1,25 @@
-package main
+// Package cryptster implements the operations exposed by the cryptster
+// command on top of the primitives found in its subpackages.
+package cryptster
 
 import (
 	"bytes"
-	"fmt"
+	"crypto/rand"
+	"hash"
 	"io"
 	"io/ioutil"
-	"math/big"
+	"strings"
+
+	"github.com/Triztian/cryptster/aes"
+	"github.com/Triztian/cryptster/classical"
+	"github.com/Triztian/cryptster/des"
+	"github.com/Triztian/cryptster/mode"
+	"github.com/Triztian/cryptster/rsa"
+	"github.com/Triztian/cryptster/sha"
 )
 
 // Perform the cipher of the data that is obtained from the reader
-func cipherText(reader io.Reader, cipher SimpleCipher, decode, verbose bool) []byte {
+func CipherText(reader io.Reader, cipher classical.SimpleCipher, decode bool) ([]byte, error) {
 	var (
 		data, results []byte
 		read          int
@@ -34,17 +44,14 @@ func cipherText(reader io.Reader, cipher SimpleCipher, decode, verbose bool) []b
 		// than `bytes.MinRead`
 		data = data[0:read]
 
-		printLn("Read "+fmt.Sprintf("%d", read)+" bytes", verbose)
-
-		if IsTransposition(cipher) {
-			printLn("Is transposition", verbose)
+		if classical.IsTransposition(cipher) {
 			cipher.SetPlaintext(data)
 		}
 
 		// After reading we encode or decode each byte
 		for n := 0; n < read; n++ {
 			var symbol, unit byte
-			if IsTransposition(cipher) {
+			if classical.IsTransposition(cipher) {
 				unit = byte(n)
 			} else {
 				unit = data[n]
@@ -60,150 +67,174 @@ func cipherText(reader io.Reader, cipher SimpleCipher, decode, verbose bool) []b
 		}
 	}
 
-	return results
-}
-
-// Perfom des3 ciphering
-func des3(reader io.Reader, key []byte, decrypt, verbose bool) []byte {
-	var (
-		des3key, data, results []byte
-	)
-
-	des3key = append(des3key, key[:16]...)
-	des3key = append(des3key, key[:8]...)
-
-	if verbose {
-		fmt.Println("DES3 Key", des3key)
+	if err != io.EOF {
+		return nil, err
 	}
 
-	des, err := NewTripleDESCipher(des3key)
+	return results, nil
+}
+
+// Perfom des3 ciphering in CBC mode, the keying option is chosen
+// by the length of the key
+func DES3(reader io.Reader, key []byte, decrypt bool) ([]byte, error) {
+	block, err := des.NewTripleDESCipher(key)
 	if err != nil {
-		panic(err)
+		return nil, err
 	}
-	data = make([]byte, 128)
-	results = make([]byte, 0)
 
-	read, err := reader.Read(data)
-	if read < 0 {
-		return []byte{}
-	}
+	return cryptAll(reader, block, ModeCBC, decrypt)
+}
 
-	data = data[:read]
-	if verbose {
-		fmt.Println("Data: ", data)
-	}
+// Create a SHA-1 hash from the data that is obtained from the reader,
+// the data is hashed as it is read
+func Hash(reader io.Reader) ([]byte, error) {
+	return HashWith(reader, "sha1")
+}
 
-	for b := 0; b < int(len(data)/BlockSize); b++ {
-		block := make([]byte, BlockSize)
-		if decrypt {
-			des.Decrypt(block, data[b:b+BlockSize])
-		} else {
-			des.Encrypt(block, data[b:b+BlockSize])
-		}
-		if verbose {
-			fmt.Println("Block: ", b, block)
-		}
-		results = append(results, block...)
+// Perform AES encryption or decryption in CBC mode, the key size
+// selects between AES-128, AES-192 and AES-256
+func AES(reader io.Reader, key []byte, decrypt bool) ([]byte, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
 	}
 
-	if verbose {
-		fmt.Println("Results: ", results)
+	return cryptAll(reader, block, ModeCBC, decrypt)
+}
+
+// Perform AES encryption or decryption in CTR mode, the data
+// is streamed from src into dst
+func AESCTR(dst io.Writer, src io.Reader, key []byte, decrypt bool) error {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return err
 	}
 
-	return results
+	return Crypt(dst, src, block, ModeCTR, decrypt)
 }
 
-// Create a hash from the data that is obtain from the reader
-func hash(reader io.Reader, verbose bool) []byte {
-	var (
-		data, msg, results []byte
-		read               int
-		err                error
-		sha                SHA
-	)
-	data = make([]byte, bytes.MinRead)
-	results = make([]byte, bytes.MinRead)
-	msg = make([]byte, 0)
-
-	read, err = reader.Read(data)
+// Perform AES-GCM authenticated encryption or decryption, the
+// additional data is authenticated but not encrypted. The random
+// nonce is prepended to the ciphertext and the tag appended to it.
+func AESGCM(reader io.Reader, key, additionalData []byte, decrypt bool) ([]byte, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
 
-	if verbose {
-		fmt.Println("Read Data:", data)
-		fmt.Println("Message: ", msg)
+	aead, err := mode.NewGCM(block)
+	if err != nil {
+		return nil, err
 	}
 
-	for read > 0 && err == nil {
-		for i := 0; i < read; i++ {
-			msg = append(msg, data[i])
-		}
+	data, err := ioutil.ReadAll(reader)
+	if err != nil {
+		return nil, err
+	}
 
-		if verbose {
-			fmt.Println("SHA Message: ", msg)
+	if decrypt {
+		if len(data) < aead.NonceSize() {
+			return nil, ErrShortInput
 		}
-		read, err = reader.Read(data)
+		nonce := data[:aead.NonceSize()]
+		return aead.Open(nil, nonce, data[aead.NonceSize():], additionalData)
 	}
 
-	sha = SHA1{}
-	results = sha.Digest(msg)
+	nonce, err := mode.RandomIV(aead.NonceSize())
+	if err != nil {
+		return nil, err
+	}
+	return aead.Seal(nonce, nonce, data, additionalData), nil
+}
 
-	return results
+// The RSA encryption paddings, the OAEP hash of each one or nil for PKCS#1 v1.5
+var rsaPaddings = map[string]func() hash.Hash{
+	"oaep-sha256": sha.NewSHA256,
+	"oaep-sha1":   sha.NewSHA1,
+	"pkcs1v15":    nil,
 }
 
-// Perform AES CBC 128 encryption
-func aes128(reader io.Reader, key []byte, verbose bool) []byte {
-	var (
-		results   []byte
-		plaintext []byte
-		cipherkey [4][4]byte
-	)
+type UnknownPaddingError string
 
-	plaintext = make([]byte, bytes.MinRead)
+func (p UnknownPaddingError) Error() string {
+	return "cryptster: unknown RSA padding " + string(p)
+}
 
-	for i := 0; i < 4; i++ {
-		for j := 0; j < 4; j++ {
-			cipherkey[i][j] = key[i*4+j]
-		}
+// Perform the RSA ciphering of the data, the key is the content of a key
+// file: the public key (or the public part of a private key) encrypts and
+// the private key decrypts. The padding is oaep-sha256, oaep-sha1 or
+// pkcs1v15; the data is split into the longest messages the padding allows
+// and each one is encrypted into a block of the size of the modulus
+func RSA(reader io.Reader, key []byte, padding string, decrypt bool) ([]byte, error) {
+	newHash, ok := rsaPaddings[strings.ToLower(padding)]
+	if !ok {
+		return nil, UnknownPaddingError(padding)
 	}
 
-	if verbose {
-		fmt.Println("Key: ", cipherkey)
+	data, err := ioutil.ReadAll(reader)
+	if err != nil {
+		return nil, err
 	}
 
-	read, err := reader.Read(plaintext)
-	if read > 0 && err == nil {
-		results = aesCBC128(plaintext[:read], cipherkey)
-	} else {
-		panic("Could not read data.")
-	}
-	return results
-}
+	if decrypt {
+		priv, err := rsa.ParsePrivateKey(key)
+		if err != nil {
+			return nil, err
+		}
 
-func rsa(reader io.Reader, key []byte, decrypt, verbose bool) []byte {
-	var (
-		data, results []byte
-	)
+		k := priv.Size()
+		if len(data) == 0 || len(data)%k != 0 {
+			return nil, rsa.ErrDecryption
+		}
 
-	data = make([]byte, bytes.MinRead)
-	results = make([]byte, bytes.MinRead)
+		results := make([]byte, 0, len(data))
+		for ; len(data) > 0; data = data[k:] {
+			var msg []byte
+			if newHash == nil {
+				msg, err = rsa.DecryptPKCS1v15(priv, data[:k])
+			} else {
+				msg, err = rsa.DecryptOAEP(newHash, priv, data[:k], nil)
+			}
+			if err != nil {
+				return nil, err
+			}
+			results = append(results, msg...)
+		}
+		return results, nil
+	}
 
-	bikey := big.NewInt(0)
-	bikey = bikey.SetBytes(key)
-	bigN := big.NewInt(N)
-	read, err := reader.Read(data)
+	pub, err := rsa.ParsePublicKey(key)
 	if err != nil {
-		panic(err)
+		return nil, err
 	}
-	if decrypt {
-		results = RSAEncrypt(data[:read], bikey, bigN)
-	} else {
-		results = RSADecrypt(data[:read], bikey, bigN)
+
+	maxLen := rsa.MaxPKCS1v15MessageLength(pub.Size())
+	if newHash != nil {
+		maxLen = rsa.MaxOAEPMessageLength(newHash, pub.Size())
+	}
+	if maxLen < 1 {
+		return nil, rsa.ErrMessageTooLong
 	}
-	return results
-}
 
-func output(data []byte, filepath string) {
-	err := ioutil.WriteFile(filepath, data, 0644)
-	if err != nil {
-		panic(err)
+	// An empty input is still encrypted into one block
+	results := make([]byte, 0)
+	for len(data) > 0 || len(results) == 0 {
+		n := len(data)
+		if n > maxLen {
+			n = maxLen
+		}
+
+		var block []byte
+		if newHash == nil {
+			block, err = rsa.EncryptPKCS1v15(rand.Reader, pub, data[:n])
+		} else {
+			block, err = rsa.EncryptOAEP(newHash, rand.Reader, pub, data[:n], nil)
+		}
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, block...)
+		data = data[n:]
 	}
+	return results, nil
 }
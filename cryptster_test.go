@@ -1,153 +1,355 @@
-package main
+package cryptster
 
 import (
 	"bytes"
+	"crypto/cipher"
+	"crypto/rand"
 	"encoding/hex"
-	"math/rand"
+	"io/ioutil"
 	"strings"
 	"testing"
-	"time"
+
+	"github.com/Triztian/cryptster/aes"
+	"github.com/Triztian/cryptster/classical"
+	"github.com/Triztian/cryptster/des"
+	"github.com/Triztian/cryptster/mode"
+	"github.com/Triztian/cryptster/rsa"
 )
 
-func compareBytes(a, b []byte) bool {
-	if len(a) != len(b) {
-		return false
+func TestCipherText(t *testing.T) {
+	var (
+		plaintext  string = "Hello"
+		ciphertext string = "Uryy|"
+	)
+
+	rot := classical.ROTCipher{Rotation: 13}
+
+	enc, err := CipherText(strings.NewReader(plaintext), rot, false)
+	if err != nil {
+		t.Fatal(err)
 	}
-	for i := 0; i < len(a); i++ {
-		if a[i] != b[i] {
-			return false
-		}
+	if string(enc) != ciphertext {
+		t.Errorf("Incorrect ROT13 encoding, got \"%s\" expected \"%s\"", enc, ciphertext)
+	}
+
+	dec, err := CipherText(strings.NewReader(ciphertext), rot, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(dec) != plaintext {
+		t.Errorf("Incorrect ROT13 decoding, got \"%s\" expected \"%s\"", dec, plaintext)
 	}
-	return true
 }
 
-func TestIntConversion(t *testing.T) {
-	var i64 uint64 = 0xFFFFFFFF00000000
-	var i32 uint32 = 0xFFFFFFFF
-	r := uint32(i64 >> 32)
-	if r != i32 {
-		t.Errorf("Unexpected int conversion, got %x expected %x", r, i32)
+func TestDES3ShortKey(t *testing.T) {
+	_, err := DES3(strings.NewReader("secret message"), []byte("short"), false)
+	if err != des.KeySizeError(5) {
+		t.Errorf("Expected des.KeySizeError(5), got %v", err)
 	}
 }
 
-func TestGetBytes(t *testing.T) {
+func TestDES3RoundTrip(t *testing.T) {
 	var (
-		x64 uint64 = 0xFFFFFFFF00000000
-		b64 []byte = []byte{255, 255, 255, 255, 255, 255, 255, 255, 0, 0, 0, 0, 0, 0, 0, 0}
-
-		x32 uint32 = 0xFFFF0000
-		b32 []byte = []byte{255, 255, 255, 255, 0, 0, 0, 0}
+		key       []byte = []byte("1234567890abcdef")
+		plaintext string = "A message longer than a single DES block"
 	)
 
-	t.Skip()
-
-	r64 := GetBytes64(x64)
-	if !compareBytes(r64, b64) {
-		t.Error("Incorrect byte extraction for uint64")
+	ciphertext, err := DES3(strings.NewReader(plaintext), key, false)
+	if err != nil {
+		t.Fatal(err)
 	}
 
-	r32 := GetBytes32(x32)
-	if !compareBytes(r32, b32) {
-		t.Error("Incorrect byte extraction for uint32")
+	decrypted, err := DES3(bytes.NewReader(ciphertext), key, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(decrypted) != plaintext {
+		t.Errorf("Incorrect round trip, expected \"%s\" got \"%s\"", plaintext, decrypted)
 	}
 }
 
-func TestGetInts(t *testing.T) {
+func TestAESCTRRoundTrip(t *testing.T) {
 	var (
-		b32 []byte = []byte{255, 255, 0, 0}
-		x32 uint32 = 0xFFFF0000
+		key       []byte = []byte("1234567890abcdef")
+		plaintext string = strings.Repeat("A message spanning several blocks. ", 100)
 	)
 
-	r32 := GetInt32(b32)
-	if r32 != x32 {
-		t.Errorf("Incorrect byte conversion got \"%x\", expected \"%x\"", r32, x32)
+	var ciphertext, decrypted bytes.Buffer
+	if err := AESCTR(&ciphertext, strings.NewReader(plaintext), key, false); err != nil {
+		t.Fatal(err)
+	}
+	if ciphertext.Len() != len(plaintext)+16 {
+		t.Errorf("Incorrect ciphertext length, expected %d got %d", len(plaintext)+16, ciphertext.Len())
+	}
+
+	if err := AESCTR(&decrypted, &ciphertext, key, true); err != nil {
+		t.Fatal(err)
+	}
+	if decrypted.String() != plaintext {
+		t.Error("Incorrect AES CTR round trip")
+	}
+
+	if err := AESCTR(&decrypted, strings.NewReader("short"), key, true); err != ErrShortInput {
+		t.Errorf("Expected ErrShortInput, got %v", err)
 	}
 }
 
-// Just to test the hex encoding
-func TestEncode(t *testing.T) {
+func TestAESGCM(t *testing.T) {
 	var (
-		b []byte = []byte{255, 255, 0, 0}
-		s string = "ffff0000"
+		key       []byte = []byte("1234567890abcdef")
+		ad        []byte = []byte("header")
+		plaintext string = "My secret message"
 	)
-	enc := hex.EncodeToString(b)
-	if s != enc {
-		t.Error("Encoding is different", s, enc)
+
+	ciphertext, err := AESGCM(strings.NewReader(plaintext), key, ad, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	decrypted, err := AESGCM(bytes.NewReader(ciphertext), key, ad, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(decrypted) != plaintext {
+		t.Errorf("Incorrect round trip, expected \"%s\" got \"%s\"", plaintext, decrypted)
+	}
+
+	ciphertext[len(ciphertext)/2] ^= 0x01
+	if _, err = AESGCM(bytes.NewReader(ciphertext), key, ad, true); err != mode.ErrOpen {
+		t.Errorf("Expected mode.ErrOpen for tampered ciphertext, got %v", err)
 	}
 }
 
-// This test case verifies that the bit rotation is done properly
-func TestLrot(t *testing.T) {
-	var (
-		x64 uint64 = 0x8000000080000000
-		r64 uint64 = 0x0000000100000001
+func TestCryptModes(t *testing.T) {
+	var plaintext string = strings.Repeat("All the block cipher modes. ", 10)
 
-		x32 uint32 = 0x80008000
-		r32 uint32 = 0x00010001
+	aesBlock, _ := aes.NewCipher([]byte("1234567890abcdef"))
+	des3Block, _ := des.NewTripleDESCipher([]byte("1234567890abcdef"))
 
-		y32 uint32 = 0xF0F0F0F0
-		s32 uint32 = 0x87878787
-	)
+	for _, block := range []cipher.Block{aesBlock, des3Block} {
+		for _, m := range []string{ModeECB, ModeCBC, ModeCFB, ModeOFB, ModeCTR} {
+			var ciphertext, decrypted bytes.Buffer
+			if err := Crypt(&ciphertext, strings.NewReader(plaintext), block, m, false); err != nil {
+				t.Fatal(m, err)
+			}
+
+			if err := Crypt(&decrypted, &ciphertext, block, m, true); err != nil {
+				t.Fatal(m, err)
+			}
+			if decrypted.String() != plaintext {
+				t.Errorf("Incorrect %s round trip, got \"%s\"", m, decrypted.String())
+			}
+		}
+	}
+
+	err := Crypt(ioutil.Discard, strings.NewReader(plaintext), aesBlock, "XTS", false)
+	if err != UnknownModeError("XTS") {
+		t.Errorf("Expected UnknownModeError, got %v", err)
+	}
+}
+
+func TestHashWith(t *testing.T) {
+	var digests = map[string]string{
+		"sha1":   "a9993e364706816aba3e25717850c26c9cd0d89d",
+		"SHA256": "ba7816bf8f01cfea414140de5dae2223b00361a396177a9cb410ff61f20015ad",
+		"sha224": "23097d223405d8228642a477bda255b32aadbce4bda0b3f7e36c9da7",
 
-	a64 := Lrot64(x64, 1)
-	if a64 != r64 {
-		t.Errorf("64-bit Left Rotation incorrect got %x expected %x", a64, r64)
+		"sha512/256": "53048e2681941ef99b2e29b76b4c7dabe4c2d0c634fc6d46e0e2f13107e7af23",
+		"SHA3-256":   "3a985da74fe225b2045c172d6bd390bd855f086e3e9d525b46bfe24511431532",
+		"shake128":   "5881092dd818bf5cf8a3ddb793fbcba74097d5c526a6d35f97b83351940f2cc8",
+		"blake2s":    "508c5e8c327c14e2e1a72ba34eeb452f37458b209ed63a294d999b4c86675982",
+		"MD5":        "900150983cd24fb0d6963f7d28e17f72",
+		"md4":        "a448017aaf21d8525fc10ae87aa6729d",
 	}
 
-	a32 := Lrot32(x32, 1)
-	if a32 != r32 {
-		t.Errorf("32-bit Left Rotation incorrect got %x expected %x", a32, r32)
+	for algorithm, digest := range digests {
+		h, err := HashWith(strings.NewReader("abc"), algorithm)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if hex.EncodeToString(h) != digest {
+			t.Errorf("Incorrect %s digest, expected %s got %x", algorithm, digest, h)
+		}
+	}
+
+	if _, err := HashWith(strings.NewReader("abc"), "sha0"); err != UnknownHashError("sha0") {
+		t.Errorf("Expected UnknownHashError, got %v", err)
 	}
 
-	b32 := Lrot32(y32, 3)
-	if b32 != s32 {
-		t.Errorf("32-bit Left 3 Rotation incorrect got %x expected %x", b32, s32)
+	if !IsInsecureHash("MD5") || !IsInsecureHash("md4") || IsInsecureHash("sha256") {
+		t.Error("Incorrect insecure hash algorithms")
 	}
 }
 
-func TestSHA(t *testing.T) {
-	var messages = map[string]string{
-		"A": "6dcd4ce23d88e2ee9568ba546c007c63d9131c1b",
-		"The quick brown fox jumps over the lazy dog": "2fd4e1c67a2d28fced849ee1bb76e7391b93eb12",
+func TestHashLength(t *testing.T) {
+	h, err := HashLength(strings.NewReader("abc"), "shake128", 8)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hex.EncodeToString(h) != "5881092dd818bf5c" {
+		t.Errorf("Incorrect SHAKE128 output, expected 5881092dd818bf5c got %x", h)
 	}
 
-	dmsg := make([]byte, bytes.MinRead)
+	h, err = HashLength(strings.NewReader("abc"), "blake2b", 32)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hex.EncodeToString(h) != "bddd813c634239723171ef3fee98579b94964e3bb1cb3e427262c8c068d52319" {
+		t.Errorf("Incorrect BLAKE2b-256 digest, got %x", h)
+	}
 
-	sha := SHA1{}
-	for message, dig := range messages {
-		reader := strings.NewReader(message)
+	if _, err := HashLength(strings.NewReader("abc"), "sha256", 8); err != ErrFixedLength {
+		t.Errorf("Expected ErrFixedLength, got %v", err)
+	}
+}
 
-		read, err := reader.Read(dmsg)
-		if read > 0 && err == nil {
-			computedHex := hex.EncodeToString(sha.Digest(dmsg[:read]))
-			if computedHex != dig {
-				t.Errorf("Incorrect digest SHA1(\"%s\") should be \"%s\" but was \"%s\"", message, dig, computedHex)
-			}
+func TestHMACWith(t *testing.T) {
+	mac, err := HMACWith(strings.NewReader("what do ya want for nothing?"), "sha256", []byte("Jefe"))
+	if err != nil {
+		t.Fatal(err)
+	}
 
-			read, err = reader.Read(dmsg)
-		} else {
-			t.Error("Could not read data", err)
-		}
+	expected := "5bdcc146bf60754e6a042426089575c75a003f089d2739839dec58b964ec3843"
+	if hex.EncodeToString(mac) != expected {
+		t.Errorf("Incorrect HMAC-SHA-256, expected %s got %x", expected, mac)
+	}
+
+	if err := VerifyHMAC(strings.NewReader("what do ya want for nothing?"), "sha256", []byte("Jefe"), mac); err != nil {
+		t.Errorf("Expected the HMAC to verify, got %v", err)
+	}
+
+	mac[0] ^= 1
+	if err := VerifyHMAC(strings.NewReader("what do ya want for nothing?"), "sha256", []byte("Jefe"), mac); err != ErrMACMismatch {
+		t.Errorf("Expected ErrMACMismatch, got %v", err)
 	}
 }
 
-// Generate a random byte
-func randByte() byte {
-	rand.Seed(time.Now().UTC().UnixNano())
-	return byte(rand.Int())
+func TestPassphraseHeader(t *testing.T) {
+	header, err := NewPassphraseHeader()
+	if err != nil {
+		t.Fatal(err)
+	}
+	header.Iterations = 1000
+
+	var buf bytes.Buffer
+	if _, err := header.WriteTo(&buf); err != nil {
+		t.Fatal(err)
+	}
+	if buf.Len() != SaltSize+4 {
+		t.Errorf("Incorrect header size %d", buf.Len())
+	}
+
+	read, err := ReadPassphraseHeader(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(read.Salt, header.Salt) || read.Iterations != header.Iterations {
+		t.Errorf("Incorrect header, expected %x/%d got %x/%d", header.Salt, header.Iterations, read.Salt, read.Iterations)
+	}
+	if !bytes.Equal(read.Key([]byte("secret"), 32), header.Key([]byte("secret"), 32)) {
+		t.Error("The keys of equal headers are different")
+	}
+
+	if _, err := ReadPassphraseHeader(bytes.NewReader(header.Salt)); err != ErrInvalidHeader {
+		t.Errorf("Expected ErrInvalidHeader for a short header, got %v", err)
+	}
+	if _, err := ReadPassphraseHeader(bytes.NewReader(make([]byte, SaltSize+4))); err != ErrInvalidHeader {
+		t.Errorf("Expected ErrInvalidHeader for 0 iterations, got %v", err)
+	}
 }
 
-func TestAES(t *testing.T) {
-	var (
-		b   byte = 0xAF
-		sbx byte = 0x79
+func TestDeriveKey(t *testing.T) {
+	encKey, err := DeriveKey("sha256", []byte("master key"), nil, []byte("encryption"), 32)
+	if err != nil {
+		t.Fatal(err)
+	}
+	macKey, err := DeriveKey("sha256", []byte("master key"), nil, []byte("authentication"), 32)
+	if err != nil {
+		t.Fatal(err)
+	}
 
-		//s   string = "A"
-		//key string = "secret"
-	)
+	if len(encKey) != 32 || len(macKey) != 32 {
+		t.Errorf("Incorrect key lengths %d and %d", len(encKey), len(macKey))
+	}
+	if bytes.Equal(encKey, macKey) {
+		t.Error("Keys with different info strings are equal")
+	}
+
+	if _, err := DeriveKey("sha0", []byte("master key"), nil, nil, 32); err != UnknownHashError("sha0") {
+		t.Errorf("Expected UnknownHashError, got %v", err)
+	}
+}
 
-	sb := subByte(b)
-	if sb != sbx {
-		t.Errorf("Incorrect subByte for 0x%x, expected %x got %x", b, sbx, sb)
+func TestMACWith(t *testing.T) {
+	var macs = []struct {
+		algorithm string
+		length    int
+		mac       string
+	}{
+		{"blake2b", 0, "204c828c56fbe6dfe80f110efd16649b9baaad573a6fe4a9a3f492857ec46f8f01eb46d3d6b777f014802967b258fdf631947e68e70cbf9054edf69fa3bbb4a8"},
+		{"blake2s", 16, "9af4e6ccbbfafb7c9dbc6088ca27f3da"},
+		{"sha1", 0, "694abd10842d161ddbc54df8a0d57cf64d0dbcc9"},
+	}
+
+	for _, m := range macs {
+		mac, err := MACWith(strings.NewReader("abc"), m.algorithm, []byte("secret"), m.length)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if hex.EncodeToString(mac) != m.mac {
+			t.Errorf("Incorrect %s MAC, expected %s got %x", m.algorithm, m.mac, mac)
+		}
+
+		if err := VerifyMAC(strings.NewReader("abc"), m.algorithm, []byte("secret"), m.length, mac); err != nil {
+			t.Errorf("Expected the %s MAC to verify, got %v", m.algorithm, err)
+		}
+	}
+
+	if _, err := MACWith(strings.NewReader("abc"), "sha256", []byte("secret"), 16); err != ErrFixedLength {
+		t.Errorf("Expected ErrFixedLength, got %v", err)
+	}
+}
+
+func TestRSA(t *testing.T) {
+	priv, err := rsa.GenerateKey(rand.Reader, 1024)
+	if err != nil {
+		t.Fatal(err)
+	}
+	privKey := rsa.MarshalPrivateKey(priv, rsa.PKCS8)
+	pubKey := rsa.MarshalPublicKey(&priv.PublicKey, rsa.PKCS8)
+
+	// 300 bytes span several blocks of every padding
+	long := bytes.Repeat([]byte("0123456789"), 30)
+	for _, padding := range []string{"oaep-sha256", "oaep-sha1", "pkcs1v15"} {
+		for _, msg := range [][]byte{{}, []byte("My secret message"), long} {
+			c, err := RSA(bytes.NewReader(msg), pubKey, padding, false)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(c)%128 != 0 {
+				t.Errorf("Incorrect %s ciphertext size %d", padding, len(c))
+			}
+
+			m, err := RSA(bytes.NewReader(c), privKey, padding, true)
+			if err != nil {
+				t.Errorf("Incorrect %s decryption, %v", padding, err)
+			} else if !bytes.Equal(m, msg) {
+				t.Errorf("Incorrect %s plaintext, expected %q got %q", padding, msg, m)
+			}
+		}
+	}
+
+	c, err := RSA(strings.NewReader("My secret message"), pubKey, "oaep-sha256", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := RSA(bytes.NewReader(c), privKey, "oaep-sha1", true); err != rsa.ErrDecryption {
+		t.Errorf("Expected ErrDecryption with a different padding, got %v", err)
+	}
+	if _, err := RSA(bytes.NewReader(c[1:]), privKey, "oaep-sha256", true); err != rsa.ErrDecryption {
+		t.Errorf("Expected ErrDecryption for a truncated ciphertext, got %v", err)
+	}
+	if _, err := RSA(bytes.NewReader(c), pubKey, "oaep-md5", false); err != UnknownPaddingError("oaep-md5") {
+		t.Errorf("Expected UnknownPaddingError, got %v", err)
 	}
 }
@@ -0,0 +1,152 @@
+// Package des implements the Data Encryption Standard (DES), the
+// Triple Data Encryption Algorithm (TDEA) and DES-X.
+package des
+
+import (
+	"crypto/cipher"
+	"errors"
+	"strconv"
+)
+
+// The DES block size in bytes.
+const BlockSize = 8
+
+// The Triple DES keying options of NIST SP 800-67.
+const (
+	// K1, K2 and K3 are independent; a 24 byte key.
+	KeyingOption1 = 1
+	// K1 and K2 are independent and K3 = K1; a 16 byte key.
+	KeyingOption2 = 2
+	// K1 = K2 = K3; an 8 byte key, this is equivalent to single DES.
+	KeyingOption3 = 3
+)
+
+var ErrDegenerateKey = errors.New("cryptster/des: degenerate Triple DES key, K1 = K2 or K2 = K3")
+
+type KeySizeError int
+
+func (k KeySizeError) Error() string {
+	return "cryptster/des: invalid key size " + strconv.Itoa(int(k))
+}
+
+// desCipher is an instance of DES encryption.
+type desCipher struct {
+	subkeys [16]uint64
+}
+
+// NewCipher creates and returns a new cipher.Block.
+// Weak and semi-weak keys are rejected with a WeakKeyError.
+func NewCipher(key []byte) (cipher.Block, error) {
+	return NewCipherParity(key, ParityIgnore)
+}
+
+// NewCipherParity creates and returns a new cipher.Block, the key
+// is validated with the parity policy.
+func NewCipherParity(key []byte, parity Parity) (cipher.Block, error) {
+	if len(key) != 8 {
+		return nil, KeySizeError(len(key))
+	}
+
+	key, err := ValidateKey(key, parity)
+	if err != nil {
+		return nil, err
+	}
+
+	c := new(desCipher)
+	c.generateSubkeys(key)
+	return c, nil
+}
+
+func (c *desCipher) BlockSize() int { return BlockSize }
+
+func (c *desCipher) Encrypt(dst, src []byte) { encryptBlock(c.subkeys[:], dst, src) }
+
+func (c *desCipher) Decrypt(dst, src []byte) { decryptBlock(c.subkeys[:], dst, src) }
+
+// A tripleDESCipher is an instance of TripleDES encryption.
+type tripleDESCipher struct {
+	cipher1, cipher2, cipher3 desCipher
+}
+
+// NewTripleDESCipher creates and returns a new cipher.Block.
+// The keying option is chosen by the length of the key: 24 bytes for
+// K1 || K2 || K3, 16 bytes for K1 || K2 and 8 bytes for K1. Keys of the
+// first two options where K1 = K2 or K2 = K3 are rejected with
+// ErrDegenerateKey since they collapse into single DES, weak and
+// semi-weak keys are rejected with a WeakKeyError.
+func NewTripleDESCipher(key []byte) (cipher.Block, error) {
+	return NewTripleDESCipherParity(key, ParityIgnore)
+}
+
+// NewTripleDESCipherParity creates and returns a new cipher.Block, every
+// DES key is validated with the parity policy.
+func NewTripleDESCipherParity(key []byte, parity Parity) (cipher.Block, error) {
+	var k1, k2, k3 []byte
+
+	if KeyingOption(len(key)) == 0 {
+		return nil, KeySizeError(len(key))
+	}
+
+	key, err := ValidateKey(key, parity)
+	if err != nil {
+		return nil, err
+	}
+
+	switch KeyingOption(len(key)) {
+	case KeyingOption1:
+		k1, k2, k3 = key[:8], key[8:16], key[16:]
+	case KeyingOption2:
+		k1, k2, k3 = key[:8], key[8:16], key[:8]
+	case KeyingOption3:
+		k1, k2, k3 = key, key, key
+	}
+
+	if len(key) > 8 && (equalKeys(k1, k2) || equalKeys(k2, k3)) {
+		return nil, ErrDegenerateKey
+	}
+
+	c := new(tripleDESCipher)
+	c.cipher1.generateSubkeys(k1)
+	c.cipher2.generateSubkeys(k2)
+	c.cipher3.generateSubkeys(k3)
+	return c, nil
+}
+
+// Obtain the Triple DES keying option for a key of the given length,
+// zero is returned if the length is not 24, 16 or 8 bytes.
+func KeyingOption(keyLen int) int {
+	switch keyLen {
+	case 24:
+		return KeyingOption1
+	case 16:
+		return KeyingOption2
+	case 8:
+		return KeyingOption3
+	}
+	return 0
+}
+
+// Determine if two DES keys are equal, the parity bits are ignored
+// since they are not used by the key schedule.
+func equalKeys(a, b []byte) bool {
+	for i := range a {
+		if a[i]&0xfe != b[i]&0xfe {
+			return false
+		}
+	}
+	return true
+}
+
+func (c *tripleDESCipher) BlockSize() int { return BlockSize }
+
+func (c *tripleDESCipher) Encrypt(dst, src []byte) {
+	c.cipher1.Encrypt(dst, src)
+	c.cipher2.Decrypt(dst, dst)
+	c.cipher3.Encrypt(dst, dst)
+}
+
+func (c *tripleDESCipher) Decrypt(dst, src []byte) {
+	c.cipher3.Decrypt(dst, src)
+	c.cipher2.Encrypt(dst, dst)
+	c.cipher1.Decrypt(dst, dst)
+}
@@ -1,4 +1,4 @@
-package main
+package des
 
 // Used to perform an initial permutation of a 64-bit input block.
 var initialPermutation = [64]byte{
@@ -0,0 +1,166 @@
+package des
+
+import (
+	"encoding/hex"
+	"testing"
+)
+
+// Single DES ECB vectors
+var desVectors = []struct {
+	key, plaintext, ciphertext string
+}{
+	// The worked example of J. Orlin Grabbe, "The DES Algorithm Illustrated"
+	{"133457799bbcdff1", "0123456789abcdef", "85e813540f0ab405"},
+	// FIPS 81 Appendix B, "Now is t"
+	{"0123456789abcdef", "4e6f772069732074", "3fa40e8a984d4815"},
+	{"0000000000000000", "0000000000000000", "8ca64de9c1b123a7"},
+	{"ffffffffffffffff", "ffffffffffffffff", "7359b2163e4edc58"},
+}
+
+func TestDES(t *testing.T) {
+	for _, v := range desVectors {
+		key, _ := hex.DecodeString(v.key)
+		plaintext, _ := hex.DecodeString(v.plaintext)
+
+		// Some vectors use weak keys, which NewCipher rejects
+		c := new(desCipher)
+		c.generateSubkeys(key)
+
+		dst := make([]byte, BlockSize)
+		c.Encrypt(dst, plaintext)
+		if hex.EncodeToString(dst) != v.ciphertext {
+			t.Errorf("Incorrect encryption with key %s, expected %s got %x", v.key, v.ciphertext, dst)
+		}
+
+		c.Decrypt(dst, dst)
+		if hex.EncodeToString(dst) != v.plaintext {
+			t.Errorf("Incorrect decryption with key %s, expected %s got %x", v.key, v.plaintext, dst)
+		}
+	}
+}
+
+// Ronald Rivest, "Testing Implementations of DES": starting from x0 every
+// step uses x as both key and data, encrypting on even steps and decrypting
+// on odd ones; x16 must equal the expected value.
+func TestDESRivest(t *testing.T) {
+	var (
+		x0  string = "9474b8e8c73bca7d"
+		x16 string = "1b1a2ddb4c642438"
+	)
+
+	x, _ := hex.DecodeString(x0)
+	for i := 0; i < 16; i++ {
+		c, err := NewCipher(x)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		next := make([]byte, BlockSize)
+		if i%2 == 0 {
+			c.Encrypt(next, x)
+		} else {
+			c.Decrypt(next, x)
+		}
+		x = next
+	}
+
+	if hex.EncodeToString(x) != x16 {
+		t.Errorf("Incorrect Rivest test result, expected %s got %x", x16, x)
+	}
+}
+
+func TestDESKeySize(t *testing.T) {
+	if _, err := NewCipher(make([]byte, 7)); err != KeySizeError(7) {
+		t.Errorf("Expected KeySizeError(7), got %v", err)
+	}
+	if _, err := NewTripleDESCipher(make([]byte, 20)); err != KeySizeError(20) {
+		t.Errorf("Expected KeySizeError(20), got %v", err)
+	}
+}
+
+const (
+	tdeaK1 string = "0123456789abcdef"
+	tdeaK2 string = "23456789abcdef01"
+	tdeaK3 string = "456789abcdef0123"
+)
+
+// NIST SP 800-67 example and the same data for the other keying options
+var tripleDESVectors = []struct {
+	key, ciphertext string
+}{
+	{tdeaK1 + tdeaK2 + tdeaK3, "a826fd8ce53b855fcce21c8112256fe668d5c05dd9b6b900"},
+	{tdeaK1 + tdeaK2, "c44862f70cf2fbdc9077d0909fa91b884cabd61fc58e0cbb"},
+	{tdeaK1 + tdeaK2 + tdeaK1, "c44862f70cf2fbdc9077d0909fa91b884cabd61fc58e0cbb"},
+}
+
+func TestTripleDES(t *testing.T) {
+	var plaintext string = "The qufck brown fox jump"
+
+	for _, v := range tripleDESVectors {
+		key, _ := hex.DecodeString(v.key)
+		c, err := NewTripleDESCipher(key)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		dst := make([]byte, len(plaintext))
+		for i := 0; i < len(plaintext); i += BlockSize {
+			c.Encrypt(dst[i:], []byte(plaintext[i:]))
+		}
+		if hex.EncodeToString(dst) != v.ciphertext {
+			t.Errorf("Incorrect encryption with key %s, expected %s got %x", v.key, v.ciphertext, dst)
+		}
+
+		for i := 0; i < len(dst); i += BlockSize {
+			c.Decrypt(dst[i:], dst[i:])
+		}
+		if string(dst) != plaintext {
+			t.Errorf("Incorrect decryption with key %s, got \"%s\"", v.key, dst)
+		}
+	}
+}
+
+// Keying option 3 must behave as single DES
+func TestTripleDESOption3(t *testing.T) {
+	for _, v := range desVectors {
+		key, _ := hex.DecodeString(v.key)
+		plaintext, _ := hex.DecodeString(v.plaintext)
+		if IsWeakKey(key) {
+			continue
+		}
+
+		c, err := NewTripleDESCipher(key)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		dst := make([]byte, BlockSize)
+		c.Encrypt(dst, plaintext)
+		if hex.EncodeToString(dst) != v.ciphertext {
+			t.Errorf("Incorrect keying option 3 encryption with key %s, expected %s got %x", v.key, v.ciphertext, dst)
+		}
+	}
+}
+
+func TestTripleDESDegenerateKey(t *testing.T) {
+	// 0123456789abcdee only differs from K1 in a parity bit
+	for _, key := range []string{
+		tdeaK1 + tdeaK1 + tdeaK3,
+		tdeaK1 + tdeaK2 + tdeaK2,
+		tdeaK1 + "0123456789abcdee",
+		tdeaK1 + tdeaK1,
+	} {
+		k, _ := hex.DecodeString(key)
+		if _, err := NewTripleDESCipher(k); err != ErrDegenerateKey {
+			t.Errorf("Expected ErrDegenerateKey for %s, got %v", key, err)
+		}
+	}
+}
+
+func TestKeyingOption(t *testing.T) {
+	for keyLen, option := range map[int]int{24: KeyingOption1, 16: KeyingOption2, 8: KeyingOption3, 12: 0} {
+		if o := KeyingOption(keyLen); o != option {
+			t.Errorf("Incorrect keying option for a %d byte key, expected %d got %d", keyLen, option, o)
+		}
+	}
+}
@@ -0,0 +1,58 @@
+package des
+
+import "crypto/cipher"
+
+// desxCipher is an instance of DES-X encryption, the block is XORed with
+// a pre-whitening key before DES and a post-whitening key after it.
+type desxCipher struct {
+	cipher desCipher
+	pre    [BlockSize]byte
+	post   [BlockSize]byte
+}
+
+// NewDESXCipher creates and returns a new DES-X cipher.Block. The key is
+// K || K1 || K2, 24 bytes where K is the DES key, K1 the pre-whitening key
+// and K2 the post-whitening key. Weak and semi-weak values of K are
+// rejected with a WeakKeyError.
+func NewDESXCipher(key []byte) (cipher.Block, error) {
+	if len(key) != 24 {
+		return nil, KeySizeError(len(key))
+	}
+
+	k, err := ValidateKey(key[:8], ParityIgnore)
+	if err != nil {
+		return nil, err
+	}
+
+	c := new(desxCipher)
+	c.cipher.generateSubkeys(k)
+	copy(c.pre[:], key[8:16])
+	copy(c.post[:], key[16:])
+	return c, nil
+}
+
+func (c *desxCipher) BlockSize() int { return BlockSize }
+
+// C = K2 ^ DES(K, P ^ K1)
+func (c *desxCipher) Encrypt(dst, src []byte) {
+	var whitened [BlockSize]byte
+	for i := 0; i < BlockSize; i++ {
+		whitened[i] = src[i] ^ c.pre[i]
+	}
+	c.cipher.Encrypt(dst, whitened[:])
+	for i := 0; i < BlockSize; i++ {
+		dst[i] ^= c.post[i]
+	}
+}
+
+// P = K1 ^ DES^-1(K, C ^ K2)
+func (c *desxCipher) Decrypt(dst, src []byte) {
+	var whitened [BlockSize]byte
+	for i := 0; i < BlockSize; i++ {
+		whitened[i] = src[i] ^ c.post[i]
+	}
+	c.cipher.Decrypt(dst, whitened[:])
+	for i := 0; i < BlockSize; i++ {
+		dst[i] ^= c.pre[i]
+	}
+}
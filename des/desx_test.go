@@ -0,0 +1,51 @@
+package des
+
+import (
+	"encoding/hex"
+	"testing"
+)
+
+// DES-X vectors, computed as K2 ^ DES(K, P ^ K1) with an independent
+// DES implementation (Go's crypto/des). With zero whitening keys
+// DES-X is single DES.
+var desxVectors = []struct {
+	key, plaintext, ciphertext string
+}{
+	{"0123456789abcdef" + "1011121314151617" + "18191a1b1c1d1e1f", "4e6f772069732074", "a236e212b05eac12"},
+	{"133457799bbcdff1" + "fedcba9876543210" + "0f1e2d3c4b5a6978", "0123456789abcdef", "55239e389d134d85"},
+	{"133457799bbcdff1" + "0000000000000000" + "0000000000000000", "0123456789abcdef", "85e813540f0ab405"},
+}
+
+func TestDESX(t *testing.T) {
+	for _, v := range desxVectors {
+		key, _ := hex.DecodeString(v.key)
+		plaintext, _ := hex.DecodeString(v.plaintext)
+
+		c, err := NewDESXCipher(key)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		dst := make([]byte, BlockSize)
+		c.Encrypt(dst, plaintext)
+		if hex.EncodeToString(dst) != v.ciphertext {
+			t.Errorf("Incorrect encryption with key %s, expected %s got %x", v.key, v.ciphertext, dst)
+		}
+
+		c.Decrypt(dst, dst)
+		if hex.EncodeToString(dst) != v.plaintext {
+			t.Errorf("Incorrect decryption with key %s, expected %s got %x", v.key, v.plaintext, dst)
+		}
+	}
+}
+
+func TestDESXKey(t *testing.T) {
+	if _, err := NewDESXCipher(make([]byte, 16)); err != KeySizeError(16) {
+		t.Errorf("Expected KeySizeError(16), got %v", err)
+	}
+
+	key, _ := hex.DecodeString("0101010101010101" + "1011121314151617" + "18191a1b1c1d1e1f")
+	if _, err := NewDESXCipher(key); err != WeakKeyError(0x0101010101010101) {
+		t.Errorf("Expected a WeakKeyError, got %v", err)
+	}
+}
@@ -0,0 +1,168 @@
+package des
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"fmt"
+	"io"
+)
+
+// The parity policies used when a DES key is validated.
+type Parity int
+
+const (
+	// The parity bits are ignored, as the key schedule does.
+	ParityIgnore Parity = iota
+	// Keys without odd parity are rejected with a ParityError.
+	ParityEnforce
+	// The parity bits are set before the key is used.
+	ParityFix
+)
+
+// The weak and semi-weak DES keys, with odd parity. Weak keys produce
+// identical subkeys so encryption equals decryption, semi-weak keys come
+// in pairs where one decrypts what the other encrypts.
+var weakKeys = []uint64{
+	// Weak keys
+	0x0101010101010101,
+	0xfefefefefefefefe,
+	0xe0e0e0e0f1f1f1f1,
+	0x1f1f1f1f0e0e0e0e,
+
+	// Semi-weak key pairs
+	0x011f011f010e010e, 0x1f011f010e010e01,
+	0x01e001e001f101f1, 0xe001e001f101f101,
+	0x01fe01fe01fe01fe, 0xfe01fe01fe01fe01,
+	0x1fe01fe00ef10ef1, 0xe01fe01ff10ef10e,
+	0x1ffe1ffe0efe0efe, 0xfe1ffe1ffe0efe0e,
+	0xe0fee0fef1fef1fe, 0xfee0fee0fef1fef1,
+}
+
+// The mask of the bits used by the key schedule
+const keyMask = 0xfefefefefefefefe
+
+type WeakKeyError uint64
+
+func (k WeakKeyError) Error() string {
+	return fmt.Sprintf("cryptster/des: weak or semi-weak key %016x", uint64(k))
+}
+
+type ParityError uint64
+
+func (k ParityError) Error() string {
+	return fmt.Sprintf("cryptster/des: key %016x does not have odd parity", uint64(k))
+}
+
+// Determine if the 8 byte key is one of the weak or semi-weak keys,
+// the parity bits are ignored.
+func IsWeakKey(key []byte) bool {
+	k := binary.BigEndian.Uint64(key) & keyMask
+	for _, w := range weakKeys {
+		if k == w&keyMask {
+			return true
+		}
+	}
+	return false
+}
+
+// Determine if every byte of the key has an odd number of set bits.
+func HasOddParity(key []byte) bool {
+	for _, b := range key {
+		if b != oddParity(b) {
+			return false
+		}
+	}
+	return true
+}
+
+// Obtain a copy of the key with the least significant bit of every
+// byte set so the byte has odd parity.
+func SetOddParity(key []byte) []byte {
+	k := make([]byte, len(key))
+	for i, b := range key {
+		k[i] = oddParity(b)
+	}
+	return k
+}
+
+// Set the parity bit of the byte so it has an odd number of set bits
+func oddParity(b byte) byte {
+	ones := 0
+	for i := uint(1); i < 8; i++ {
+		ones += int(b>>i) & 1
+	}
+	if ones%2 == 0 {
+		return b | 1
+	}
+	return b &^ 1
+}
+
+// Validate every 8 byte DES key within the key using the parity policy;
+// the validated key is returned, which has its parity bits set when
+// the policy is ParityFix. Weak and semi-weak keys are rejected with
+// a WeakKeyError.
+func ValidateKey(key []byte, parity Parity) ([]byte, error) {
+	if len(key) == 0 || len(key)%8 != 0 {
+		return nil, KeySizeError(len(key))
+	}
+
+	switch parity {
+	case ParityFix:
+		key = SetOddParity(key)
+	case ParityEnforce:
+		for i := 0; i < len(key); i += 8 {
+			if !HasOddParity(key[i : i+8]) {
+				return nil, ParityError(binary.BigEndian.Uint64(key[i:]))
+			}
+		}
+	}
+
+	for i := 0; i < len(key); i += 8 {
+		if IsWeakKey(key[i : i+8]) {
+			return nil, WeakKeyError(binary.BigEndian.Uint64(key[i:]))
+		}
+	}
+
+	return key, nil
+}
+
+// Generate a random DES key with odd parity that is not weak
+// or semi-weak.
+func GenerateKey() ([]byte, error) {
+	key := make([]byte, 8)
+	for {
+		if _, err := io.ReadFull(rand.Reader, key); err != nil {
+			return nil, err
+		}
+
+		key = SetOddParity(key)
+		if !IsWeakKey(key) {
+			return key, nil
+		}
+	}
+}
+
+// Generate a random Triple DES key for the keying option, every DES key
+// within it has odd parity and the key is not degenerate.
+func GenerateTripleDESKey(option int) ([]byte, error) {
+	var key []byte
+
+	if option < KeyingOption1 || option > KeyingOption3 {
+		return nil, fmt.Errorf("cryptster/des: invalid keying option %d", option)
+	}
+
+	for len(key) < 8*(4-option) {
+		k, err := GenerateKey()
+		if err != nil {
+			return nil, err
+		}
+
+		// Every key must differ from the previous one
+		if len(key) >= 8 && equalKeys(k, key[len(key)-8:]) {
+			continue
+		}
+		key = append(key, k...)
+	}
+
+	return key, nil
+}
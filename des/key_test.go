@@ -0,0 +1,112 @@
+package des
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+)
+
+func TestWeakKeys(t *testing.T) {
+	for _, w := range []string{
+		"0101010101010101",
+		"0000000000000000",
+		"fefefefefefefefe",
+		"e0e0e0e0f1f1f1f1",
+		"1f1f1f1f0e0e0e0e",
+		"011f011f010e010e",
+		"fee0fee0fef1fef1",
+	} {
+		key, _ := hex.DecodeString(w)
+		if !IsWeakKey(key) {
+			t.Errorf("Key %s should be weak", w)
+		}
+
+		if _, err := NewCipher(key); err == nil {
+			t.Errorf("Expected a WeakKeyError for %s", w)
+		} else if _, ok := err.(WeakKeyError); !ok {
+			t.Errorf("Expected a WeakKeyError for %s, got %v", w, err)
+		}
+	}
+
+	key, _ := hex.DecodeString("0123456789abcdef" + "0101010101010101" + "456789abcdef0123")
+	if _, err := NewTripleDESCipher(key); err != WeakKeyError(0x0101010101010101) {
+		t.Errorf("Expected a WeakKeyError for K2, got %v", err)
+	}
+
+	key, _ = hex.DecodeString("133457799bbcdff1")
+	if IsWeakKey(key) {
+		t.Error("Key 133457799bbcdff1 should not be weak")
+	}
+}
+
+func TestParity(t *testing.T) {
+	var (
+		odd  string = "0123456789abcdef"
+		even string = "0022446688aaccee"
+	)
+
+	oddKey, _ := hex.DecodeString(odd)
+	evenKey, _ := hex.DecodeString(even)
+
+	if !HasOddParity(oddKey) {
+		t.Errorf("Key %s should have odd parity", odd)
+	}
+	if HasOddParity(evenKey) {
+		t.Errorf("Key %s should not have odd parity", even)
+	}
+
+	fixed := SetOddParity(evenKey)
+	if !HasOddParity(fixed) {
+		t.Errorf("SetOddParity(%s) should have odd parity, got %x", even, fixed)
+	}
+	if hex.EncodeToString(evenKey) != even {
+		t.Error("SetOddParity should not modify the key")
+	}
+
+	if _, err := NewCipherParity(evenKey, ParityEnforce); err != ParityError(0x0022446688aaccee) {
+		t.Errorf("Expected a ParityError, got %v", err)
+	}
+	if _, err := NewCipherParity(oddKey, ParityEnforce); err != nil {
+		t.Errorf("Unexpected error for a key with odd parity: %v", err)
+	}
+
+	// Fixing the parity must not change the cipher
+	plaintext := []byte("12345678")
+	a, _ := NewCipherParity(evenKey, ParityFix)
+	b, _ := NewCipher(evenKey)
+	ca, cb := make([]byte, BlockSize), make([]byte, BlockSize)
+	a.Encrypt(ca, plaintext)
+	b.Encrypt(cb, plaintext)
+	if !bytes.Equal(ca, cb) {
+		t.Error("The parity bits should not change the ciphertext")
+	}
+}
+
+func TestGenerateKey(t *testing.T) {
+	for i := 0; i < 100; i++ {
+		key, err := GenerateKey()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(key) != 8 || !HasOddParity(key) || IsWeakKey(key) {
+			t.Errorf("Invalid generated key %x", key)
+		}
+	}
+
+	for option, size := range map[int]int{KeyingOption1: 24, KeyingOption2: 16, KeyingOption3: 8} {
+		key, err := GenerateTripleDESKey(option)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(key) != size {
+			t.Errorf("Incorrect key size for keying option %d, expected %d got %d", option, size, len(key))
+		}
+		if _, err := NewTripleDESCipherParity(key, ParityEnforce); err != nil {
+			t.Errorf("Invalid generated key %x: %v", key, err)
+		}
+	}
+
+	if _, err := GenerateTripleDESKey(4); err == nil {
+		t.Error("Expected an error for keying option 4")
+	}
+}
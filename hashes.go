@@ -0,0 +1,231 @@
+package cryptster
+
+import (
+	"errors"
+	"hash"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/Triztian/cryptster/hmac"
+	"github.com/Triztian/cryptster/kdf"
+	"github.com/Triztian/cryptster/md"
+	"github.com/Triztian/cryptster/sha"
+)
+
+// The hash algorithms accepted by NewHash mapped to their constructors.
+var hashAlgorithms = map[string]func() hash.Hash{
+	"sha1":       sha.NewSHA1,
+	"sha224":     sha.NewSHA224,
+	"sha256":     sha.NewSHA256,
+	"sha384":     sha.NewSHA384,
+	"sha512":     sha.NewSHA512,
+	"sha512/224": sha.NewSHA512_224,
+	"sha512/256": sha.NewSHA512_256,
+	"sha3-224":   sha.NewSHA3_224,
+	"sha3-256":   sha.NewSHA3_256,
+	"sha3-384":   sha.NewSHA3_384,
+	"sha3-512":   sha.NewSHA3_512,
+	"shake128":   func() hash.Hash { return sha.NewSHAKE128() },
+	"shake256":   func() hash.Hash { return sha.NewSHAKE256() },
+	"blake2b": func() hash.Hash {
+		d, _ := sha.NewBLAKE2b(sha.BLAKE2bSize, nil)
+		return d
+	},
+	"blake2s": func() hash.Hash {
+		d, _ := sha.NewBLAKE2s(sha.BLAKE2sSize, nil)
+		return d
+	},
+	"md4": md.NewMD4,
+	"md5": md.NewMD5,
+}
+
+// The hash algorithms that are broken and only provided
+// for legacy interoperability.
+var insecureHashes = map[string]bool{
+	"md4": true,
+	"md5": true,
+}
+
+// The hash algorithms with a configurable digest size and a keyed mode
+// mapped to their constructors.
+var keyedHashAlgorithms = map[string]func(size int, key []byte) (hash.Hash, error){
+	"blake2b": sha.NewBLAKE2b,
+	"blake2s": sha.NewBLAKE2s,
+}
+
+// ErrFixedLength is returned when an output length is requested
+// from a hash that is not an extendable-output function.
+var ErrFixedLength = errors.New("cryptster: the output length can only be set for the SHAKE and BLAKE2 functions")
+
+// ErrMACMismatch is returned when a MAC does not match the computed one.
+var ErrMACMismatch = errors.New("cryptster: the MAC does not match")
+
+type UnknownHashError string
+
+func (h UnknownHashError) Error() string {
+	return "cryptster: unknown hash algorithm " + string(h)
+}
+
+// Obtain a new hash.Hash for the algorithm, the name is not case sensitive.
+func NewHash(algorithm string) (hash.Hash, error) {
+	newHash, err := hashConstructor(algorithm)
+	if err != nil {
+		return nil, err
+	}
+	return newHash(), nil
+}
+
+// Obtain a new HMAC hash.Hash with the key over the algorithm.
+func NewHMAC(algorithm string, key []byte) (hash.Hash, error) {
+	newHash, err := hashConstructor(algorithm)
+	if err != nil {
+		return nil, err
+	}
+	return hmac.New(newHash, key), nil
+}
+
+// Determine if the hash algorithm is broken, its digests should only
+// be used to interoperate with legacy systems
+func IsInsecureHash(algorithm string) bool {
+	return insecureHashes[strings.ToLower(algorithm)]
+}
+
+func hashConstructor(algorithm string) (func() hash.Hash, error) {
+	newHash, ok := hashAlgorithms[strings.ToLower(algorithm)]
+	if !ok {
+		return nil, UnknownHashError(algorithm)
+	}
+	return newHash, nil
+}
+
+// Obtain the names of the hash algorithms accepted by NewHash
+func HashAlgorithms() []string {
+	var names []string
+	for name := range hashAlgorithms {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Create a hash of the data that is obtained from the reader using
+// the given algorithm, the data is hashed as it is read
+func HashWith(reader io.Reader, algorithm string) ([]byte, error) {
+	return HashLength(reader, algorithm, 0)
+}
+
+// Create a hash of length bytes of the data that is obtained from the
+// reader, only the SHAKE and BLAKE2 functions accept a length other
+// than 0, which selects the default size of the algorithm
+func HashLength(reader io.Reader, algorithm string, length int) ([]byte, error) {
+	if newKeyed, ok := keyedHashAlgorithms[strings.ToLower(algorithm)]; ok && length != 0 {
+		digest, err := newKeyed(length, nil)
+		if err != nil {
+			return nil, err
+		}
+		return sumReader(digest, reader)
+	}
+
+	digest, err := NewHash(algorithm)
+	if err != nil {
+		return nil, err
+	}
+
+	shake, isShake := digest.(sha.ShakeHash)
+	if length != 0 && (!isShake || length < 0) {
+		return nil, ErrFixedLength
+	}
+
+	if length == 0 {
+		return sumReader(digest, reader)
+	}
+
+	if _, err := io.Copy(digest, reader); err != nil {
+		return nil, err
+	}
+
+	output := make([]byte, length)
+	shake.Read(output)
+	return output, nil
+}
+
+// Hash the data that is obtained from the reader
+func sumReader(digest hash.Hash, reader io.Reader) ([]byte, error) {
+	if _, err := io.Copy(digest, reader); err != nil {
+		return nil, err
+	}
+	return digest.Sum(nil), nil
+}
+
+// Create the HMAC of the data that is obtained from the reader using
+// the given algorithm and key
+func HMACWith(reader io.Reader, algorithm string, key []byte) ([]byte, error) {
+	mac, err := NewHMAC(algorithm, key)
+	if err != nil {
+		return nil, err
+	}
+	return sumReader(mac, reader)
+}
+
+// Verify that expected is the HMAC of the data that is obtained from
+// the reader, ErrMACMismatch is returned if it is not. The MACs are
+// compared in constant time.
+func VerifyHMAC(reader io.Reader, algorithm string, key, expected []byte) error {
+	mac, err := HMACWith(reader, algorithm, key)
+	if err != nil {
+		return err
+	}
+
+	if !hmac.Equal(mac, expected) {
+		return ErrMACMismatch
+	}
+	return nil
+}
+
+// Create a MAC of the data that is obtained from the reader with the key,
+// the BLAKE2 functions use their keyed mode, where length selects the
+// digest size, and the other algorithms an HMAC
+func MACWith(reader io.Reader, algorithm string, key []byte, length int) ([]byte, error) {
+	name := strings.ToLower(algorithm)
+	if newKeyed, ok := keyedHashAlgorithms[name]; ok {
+		if length == 0 {
+			length = hashAlgorithms[name]().Size()
+		}
+
+		mac, err := newKeyed(length, key)
+		if err != nil {
+			return nil, err
+		}
+		return sumReader(mac, reader)
+	}
+
+	if length != 0 {
+		return nil, ErrFixedLength
+	}
+	return HMACWith(reader, algorithm, key)
+}
+
+// Verify that expected is the MAC of the data that is obtained from the
+// reader as computed by MACWith, ErrMACMismatch is returned if it is not.
+func VerifyMAC(reader io.Reader, algorithm string, key []byte, length int, expected []byte) error {
+	mac, err := MACWith(reader, algorithm, key, length)
+	if err != nil {
+		return err
+	}
+
+	if !hmac.Equal(mac, expected) {
+		return ErrMACMismatch
+	}
+	return nil
+}
+
+// Derive length bytes of key material from the secret with HKDF over
+// the algorithm, keys derived with different info strings are independent
+func DeriveKey(algorithm string, secret, salt, info []byte, length int) ([]byte, error) {
+	newHash, err := hashConstructor(algorithm)
+	if err != nil {
+		return nil, err
+	}
+	return kdf.HKDF(newHash, secret, salt, info, length)
+}
@@ -0,0 +1,75 @@
+// Package hmac implements the keyed-hash message authentication code
+// of RFC 2104 over any of the cryptster hash functions.
+package hmac
+
+import (
+	"crypto/subtle"
+	"hash"
+)
+
+const (
+	ipad byte = 0x36
+	opad byte = 0x5c
+)
+
+// hmacDigest computes H(K ^ opad, H(K ^ ipad, message)), the inner
+// hash is fed the message as it is written.
+type hmacDigest struct {
+	inner, outer hash.Hash
+	ipad, opad   []byte
+}
+
+// New returns a new hash.Hash computing the HMAC of the hash returned
+// by newHash with the given key, keys longer than the block size of
+// the hash are hashed first.
+func New(newHash func() hash.Hash, key []byte) hash.Hash {
+	h := &hmacDigest{inner: newHash(), outer: newHash()}
+
+	blockSize := h.inner.BlockSize()
+	if len(key) > blockSize {
+		h.outer.Write(key)
+		key = h.outer.Sum(nil)
+	}
+
+	h.ipad = make([]byte, blockSize)
+	h.opad = make([]byte, blockSize)
+	copy(h.ipad, key)
+	copy(h.opad, key)
+	for i := range h.ipad {
+		h.ipad[i] ^= ipad
+		h.opad[i] ^= opad
+	}
+
+	h.Reset()
+	return h
+}
+
+func (h *hmacDigest) Reset() {
+	h.inner.Reset()
+	h.inner.Write(h.ipad)
+}
+
+func (h *hmacDigest) Size() int { return h.outer.Size() }
+
+func (h *hmacDigest) BlockSize() int { return h.inner.BlockSize() }
+
+func (h *hmacDigest) Write(p []byte) (int, error) {
+	return h.inner.Write(p)
+}
+
+// Append the HMAC of the data written so far to in,
+// the state of the HMAC is not changed.
+func (h *hmacDigest) Sum(in []byte) []byte {
+	innerSum := h.inner.Sum(nil)
+
+	h.outer.Reset()
+	h.outer.Write(h.opad)
+	h.outer.Write(innerSum)
+	return h.outer.Sum(in)
+}
+
+// Determine if two MACs are equal without leaking timing information,
+// use it instead of bytes.Equal to verify a MAC.
+func Equal(mac1, mac2 []byte) bool {
+	return subtle.ConstantTimeCompare(mac1, mac2) == 1
+}
@@ -0,0 +1,56 @@
+package kdf
+
+import (
+	"errors"
+	"hash"
+
+	"github.com/Triztian/cryptster/hmac"
+)
+
+// ErrHKDFLength is returned when more than 255 hash outputs of key
+// material are requested from HKDFExpand.
+var ErrHKDFLength = errors.New("cryptster/kdf: HKDF output length too large")
+
+// Extract a pseudorandom key from the secret with HKDF (RFC 5869), an
+// empty salt is replaced by a string of zeros of the hash length.
+func HKDFExtract(newHash func() hash.Hash, secret, salt []byte) []byte {
+	if len(salt) == 0 {
+		salt = make([]byte, newHash().Size())
+	}
+
+	prf := hmac.New(newHash, salt)
+	prf.Write(secret)
+	return prf.Sum(nil)
+}
+
+// Expand the pseudorandom key into length bytes of key material bound
+// to the info string, different info strings give independent keys.
+func HKDFExpand(newHash func() hash.Hash, prk, info []byte, length int) ([]byte, error) {
+	prf := hmac.New(newHash, prk)
+	if length < 0 || length > 255*prf.Size() {
+		return nil, ErrHKDFLength
+	}
+
+	var (
+		okm = make([]byte, 0, length+prf.Size())
+		t   []byte
+	)
+
+	// T(i) = HMAC(PRK, T(i-1) || info || i)
+	for i := 1; len(okm) < length; i++ {
+		prf.Reset()
+		prf.Write(t)
+		prf.Write(info)
+		prf.Write([]byte{byte(i)})
+		t = prf.Sum(t[:0])
+		okm = append(okm, t...)
+	}
+
+	return okm[:length], nil
+}
+
+// Derive length bytes of key material from the secret with HKDF,
+// the extract and expand steps in one call.
+func HKDF(newHash func() hash.Hash, secret, salt, info []byte, length int) ([]byte, error) {
+	return HKDFExpand(newHash, HKDFExtract(newHash, secret, salt), info, length)
+}
@@ -0,0 +1,97 @@
+package kdf
+
+import (
+	"encoding/hex"
+	"hash"
+	"strings"
+	"testing"
+
+	"github.com/Triztian/cryptster/sha"
+)
+
+type hkdfVector struct {
+	newHash          func() hash.Hash
+	secret, salt     string
+	info             string
+	length           int
+	prk, keyMaterial string
+}
+
+// Obtain the hex encoding of the bytes from to to-1
+func byteRange(from, to int) string {
+	b := make([]byte, 0, to-from)
+	for i := from; i < to; i++ {
+		b = append(b, byte(i))
+	}
+	return hex.EncodeToString(b)
+}
+
+// RFC 5869 test cases 1 to 7
+var hkdfVectors = []hkdfVector{
+	{
+		sha.NewSHA256, strings.Repeat("0b", 22), byteRange(0x00, 0x0d), byteRange(0xf0, 0xfa), 42,
+		"077709362c2e32df0ddc3f0dc47bba6390b6c73bb50f9c3122ec844ad7c2b3e5",
+		"3cb25f25faacd57a90434f64d0362f2a2d2d0a90cf1a5a4c5db02d56ecc4c5bf34007208d5b887185865",
+	},
+	{
+		sha.NewSHA256, byteRange(0x00, 0x50), byteRange(0x60, 0xb0), byteRange(0xb0, 0x100), 82,
+		"06a6b88c5853361a06104c9ceb35b45cef760014904671014a193f40c15fc244",
+		"b11e398dc80327a1c8e7f78c596a49344f012eda2d4efad8a050cc4c19afa97c59045a99cac7827271cb41c65e590e09da3275600c2f09b8367793a9aca3db71cc30c58179ec3e87c14c01d5c1f3434f1d87",
+	},
+	{
+		sha.NewSHA256, strings.Repeat("0b", 22), "", "", 42,
+		"19ef24a32c717b167f33a91d6f648bdf96596776afdb6377ac434c1c293ccb04",
+		"8da4e775a563c18f715f802a063c5a31b8a11f5c5ee1879ec3454e5f3c738d2d9d201395faa4b61a96c8",
+	},
+	{
+		sha.NewSHA1, strings.Repeat("0b", 11), byteRange(0x00, 0x0d), byteRange(0xf0, 0xfa), 42,
+		"9b6c18c432a7bf8f0e71c8eb88f4b30baa2ba243",
+		"085a01ea1b10f36933068b56efa5ad81a4f14b822f5b091568a9cdd4f155fda2c22e422478d305f3f896",
+	},
+	{
+		sha.NewSHA1, byteRange(0x00, 0x50), byteRange(0x60, 0xb0), byteRange(0xb0, 0x100), 82,
+		"8adae09a2a307059478d309b26c4115a224cfaf6",
+		"0bd770a74d1160f7c9f12cd5912a06ebff6adcae899d92191fe4305673ba2ffe8fa3f1a4e5ad79f3f334b3b202b2173c486ea37ce3d397ed034c7f9dfeb15c5e927336d0441f4c4300e2cff0d0900b52d3b4",
+	},
+	{
+		sha.NewSHA1, strings.Repeat("0b", 22), "", "", 42,
+		"da8c8a73c7fa77288ec6f5e7c297786aa0d32d01",
+		"0ac1af7002b3d761d1e55298da9d0506b9ae52057220a306e07b6b87e8df21d0ea00033de03984d34918",
+	},
+	{
+		sha.NewSHA1, strings.Repeat("0c", 22), "", "", 42,
+		"2adccada18779e7c2077ad2eb19d3f3e731385dd",
+		"2c91117204d745f3500d636a62f64f0ab3bae548aa53d423b0d1f27ebba6f5e5673a081d70cce7acfc48",
+	},
+}
+
+func TestHKDF(t *testing.T) {
+	for i, v := range hkdfVectors {
+		secret, _ := hex.DecodeString(v.secret)
+		salt, _ := hex.DecodeString(v.salt)
+		info, _ := hex.DecodeString(v.info)
+
+		prk := HKDFExtract(v.newHash, secret, salt)
+		if computedHex := hex.EncodeToString(prk); computedHex != v.prk {
+			t.Errorf("Incorrect PRK of case %d, expected %s got %s", i+1, v.prk, computedHex)
+		}
+
+		okm, err := HKDF(v.newHash, secret, salt, info, v.length)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if computedHex := hex.EncodeToString(okm); computedHex != v.keyMaterial {
+			t.Errorf("Incorrect OKM of case %d, expected %s got %s", i+1, v.keyMaterial, computedHex)
+		}
+	}
+}
+
+func TestHKDFLength(t *testing.T) {
+	prk := HKDFExtract(sha.NewSHA256, []byte("secret"), nil)
+	if _, err := HKDFExpand(sha.NewSHA256, prk, nil, 255*32); err != nil {
+		t.Errorf("Expected 255 blocks of key material, got %v", err)
+	}
+	if _, err := HKDFExpand(sha.NewSHA256, prk, nil, 255*32+1); err != ErrHKDFLength {
+		t.Errorf("Expected ErrHKDFLength, got %v", err)
+	}
+}
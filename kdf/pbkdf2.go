@@ -0,0 +1,46 @@
+// Package kdf implements the key derivation functions of cryptster,
+// they derive keys from passwords or other keys using an HMAC.
+package kdf
+
+import (
+	"hash"
+
+	"github.com/Triztian/cryptster/byteutil"
+	"github.com/Triztian/cryptster/hmac"
+)
+
+// Derive a key of keyLen bytes from the password and salt with PBKDF2
+// (RFC 8018) using the HMAC of the hash returned by newHash.
+func PBKDF2(password, salt []byte, iterations, keyLen int, newHash func() hash.Hash) []byte {
+	prf := hmac.New(newHash, password)
+	hLen := prf.Size()
+	blocks := (keyLen + hLen - 1) / hLen
+
+	var (
+		key = make([]byte, 0, blocks*hLen)
+		u   = make([]byte, hLen)
+		t   = make([]byte, hLen)
+	)
+
+	for i := 1; i <= blocks; i++ {
+		// U_1 = PRF(P, S || INT(i))
+		prf.Reset()
+		prf.Write(salt)
+		prf.Write(byteutil.GetBytes32(uint32(i)))
+		u = prf.Sum(u[:0])
+		copy(t, u)
+
+		// T_i = U_1 ^ U_2 ^ ... ^ U_c
+		for j := 1; j < iterations; j++ {
+			prf.Reset()
+			prf.Write(u)
+			u = prf.Sum(u[:0])
+			for k := range t {
+				t[k] ^= u[k]
+			}
+		}
+		key = append(key, t...)
+	}
+
+	return key[:keyLen]
+}
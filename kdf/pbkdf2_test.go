@@ -0,0 +1,47 @@
+package kdf
+
+import (
+	"encoding/hex"
+	"hash"
+	"testing"
+
+	"github.com/Triztian/cryptster/sha"
+)
+
+type pbkdf2Vector struct {
+	password, salt string
+	iterations     int
+	key            string
+}
+
+func testPBKDF2(t *testing.T, name string, newHash func() hash.Hash, vectors []pbkdf2Vector) {
+	for _, v := range vectors {
+		key := PBKDF2([]byte(v.password), []byte(v.salt), v.iterations, len(v.key)/2, newHash)
+		if computedHex := hex.EncodeToString(key); computedHex != v.key {
+			t.Errorf("Incorrect %s key with %d iterations, expected %s got %s", name, v.iterations, v.key, computedHex)
+		}
+	}
+}
+
+// RFC 6070 test vectors
+var pbkdf2SHA1Vectors = []pbkdf2Vector{
+	{"password", "salt", 1, "0c60c80f961f0e71f3a9b524af6012062fe037a6"},
+	{"password", "salt", 2, "ea6c014dc72d6f8ccd1ed92ace1d41f0d8de8957"},
+	{"password", "salt", 4096, "4b007901b765489abead49d926f721d065a429c1"},
+	{"passwordPASSWORDpassword", "saltSALTsaltSALTsaltSALTsaltSALTsalt", 4096, "3d2eec4fe41c849b80c8d83662c0e44a8b291a964cf2f07038"},
+	{"pass\x00word", "sa\x00lt", 4096, "56fa6aa75548099dcc37d7f03425e0c3"},
+}
+
+// RFC 7914 section 11 test vectors
+var pbkdf2SHA256Vectors = []pbkdf2Vector{
+	{"passwd", "salt", 1, "55ac046e56e3089fec1691c22544b605f94185216dde0465e68b9d57c20dacbc49ca9cccf179b645991664b39d77ef317c71b845b1e30bd509112041d3a19783"},
+	{"Password", "NaCl", 80000, "4ddcd8f60b98be21830cee5ef22701f9641a4418d04c0414aeff08876b34ab56a1d425a1225833549adb841b51c9b3176a272bdebba1d078478f62b397f33c8d"},
+}
+
+func TestPBKDF2SHA1(t *testing.T) {
+	testPBKDF2(t, "PBKDF2-HMAC-SHA-1", sha.NewSHA1, pbkdf2SHA1Vectors)
+}
+
+func TestPBKDF2SHA256(t *testing.T) {
+	testPBKDF2(t, "PBKDF2-HMAC-SHA-256", sha.NewSHA256, pbkdf2SHA256Vectors)
+}
@@ -0,0 +1,94 @@
+// Package md implements the MD4 and MD5 message digests. They are broken
+// and only provided to interoperate with legacy systems, use the
+// functions of the sha package for anything else.
+package md
+
+import (
+	"encoding/binary"
+	"hash"
+)
+
+const (
+	// The size of an MD4 or MD5 digest in bytes.
+	Size = 16
+
+	// The block size of MD4 and MD5 in bytes.
+	BlockSize = 64
+)
+
+// The initial state of MD4 and MD5
+var mdInit = [4]uint32{0x67452301, 0xefcdab89, 0x98badcfe, 0x10325476}
+
+// mdDigest is the incremental state of an MD4 or MD5 computation, they
+// share the padding and only differ in the function processing a block.
+type mdDigest struct {
+	h     [4]uint32
+	x     [BlockSize]byte
+	nx    int
+	len   uint64
+	block func(h *[4]uint32, p []byte)
+}
+
+func newDigest(block func(h *[4]uint32, p []byte)) hash.Hash {
+	d := &mdDigest{block: block}
+	d.Reset()
+	return d
+}
+
+func (d *mdDigest) Reset() {
+	d.h = mdInit
+	d.nx = 0
+	d.len = 0
+}
+
+func (d *mdDigest) Size() int { return Size }
+
+func (d *mdDigest) BlockSize() int { return BlockSize }
+
+func (d *mdDigest) Write(p []byte) (int, error) {
+	n := len(p)
+	d.len += uint64(n)
+
+	// Complete the buffered block first
+	if d.nx > 0 {
+		c := copy(d.x[d.nx:], p)
+		d.nx += c
+		p = p[c:]
+		if d.nx == BlockSize {
+			d.block(&d.h, d.x[:])
+			d.nx = 0
+		}
+	}
+
+	if len(p) >= BlockSize {
+		full := len(p) &^ (BlockSize - 1)
+		d.block(&d.h, p[:full])
+		p = p[full:]
+	}
+
+	d.nx += copy(d.x[:], p)
+	return n, nil
+}
+
+// Append the digest of the data written so far to in,
+// the state of the hash is not changed.
+func (d *mdDigest) Sum(in []byte) []byte {
+	c := *d
+
+	// Pad with a 1 bit and 0's until the length is 448 (mod 512)
+	// followed by the length in bits (Little-Endian)
+	var pad [BlockSize + 8]byte
+	pad[0] = 0x80
+	n := 56 - c.len%64
+	if c.len%64 >= 56 {
+		n += 64
+	}
+	binary.LittleEndian.PutUint64(pad[n:], c.len*8)
+	c.Write(pad[:n+8])
+
+	var digest [Size]byte
+	for i, h := range c.h {
+		binary.LittleEndian.PutUint32(digest[i*4:], h)
+	}
+	return append(in, digest[:]...)
+}
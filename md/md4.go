@@ -0,0 +1,81 @@
+package md
+
+import (
+	"encoding/binary"
+	"hash"
+
+	"github.com/Triztian/cryptster/byteutil"
+)
+
+const (
+	// The constants added in the second and third rounds of MD4
+	md4K2 uint32 = 0x5a827999
+	md4K3 uint32 = 0x6ed9eba1
+)
+
+// The order in which the message words are used by the second
+// and third rounds of MD4
+var (
+	md4Order2 = [16]int{0, 4, 8, 12, 1, 5, 9, 13, 2, 6, 10, 14, 3, 7, 11, 15}
+	md4Order3 = [16]int{0, 8, 4, 12, 2, 10, 6, 14, 1, 9, 5, 13, 3, 11, 7, 15}
+)
+
+// The left rotations of each round, indexed by the step number mod 4
+var md4Shifts = [3][4]uint32{
+	{3, 7, 11, 19},
+	{3, 5, 9, 13},
+	{3, 9, 11, 15},
+}
+
+// MD4 computes MD4 digests.
+type MD4 struct{}
+
+// Perform an MD4 message digest
+func (md MD4) Digest(message []byte) []byte {
+	d := NewMD4()
+	d.Write(message)
+	return d.Sum(nil)
+}
+
+// NewMD4 returns a new hash.Hash computing the MD4 checksum (RFC 1320).
+func NewMD4() hash.Hash {
+	return newDigest(md4Block)
+}
+
+// Process the 64 byte chunks of the message
+func md4Block(h *[4]uint32, p []byte) {
+	var x [16]uint32
+
+	for len(p) >= BlockSize {
+		for i := range x {
+			x[i] = binary.LittleEndian.Uint32(p[i*4:])
+		}
+
+		a, b, c, d := h[0], h[1], h[2], h[3]
+
+		for i := 0; i < 48; i++ {
+			var f, w uint32
+
+			switch i / 16 {
+			case 0:
+				f = (b & c) | (^b & d)
+				w = x[i]
+			case 1:
+				f = (b & c) | (b & d) | (c & d)
+				w = x[md4Order2[i%16]] + md4K2
+			default:
+				f = b ^ c ^ d
+				w = x[md4Order3[i%16]] + md4K3
+			}
+
+			a, b, c, d = d, byteutil.Lrot32(a+f+w, md4Shifts[i/16][i%4]), b, c
+		}
+
+		h[0] += a
+		h[1] += b
+		h[2] += c
+		h[3] += d
+
+		p = p[BlockSize:]
+	}
+}
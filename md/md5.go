@@ -0,0 +1,90 @@
+package md
+
+import (
+	"encoding/binary"
+	"hash"
+
+	"github.com/Triztian/cryptster/byteutil"
+)
+
+// The constants of the MD5 steps, the integer part of
+// 2^32 * abs(sin(i)) for i from 1 to 64
+var md5T = [64]uint32{
+	0xd76aa478, 0xe8c7b756, 0x242070db, 0xc1bdceee, 0xf57c0faf, 0x4787c62a, 0xa8304613, 0xfd469501,
+	0x698098d8, 0x8b44f7af, 0xffff5bb1, 0x895cd7be, 0x6b901122, 0xfd987193, 0xa679438e, 0x49b40821,
+	0xf61e2562, 0xc040b340, 0x265e5a51, 0xe9b6c7aa, 0xd62f105d, 0x02441453, 0xd8a1e681, 0xe7d3fbc8,
+	0x21e1cde6, 0xc33707d6, 0xf4d50d87, 0x455a14ed, 0xa9e3e905, 0xfcefa3f8, 0x676f02d9, 0x8d2a4c8a,
+	0xfffa3942, 0x8771f681, 0x6d9d6122, 0xfde5380c, 0xa4beea44, 0x4bdecfa9, 0xf6bb4b60, 0xbebfbc70,
+	0x289b7ec6, 0xeaa127fa, 0xd4ef3085, 0x04881d05, 0xd9d4d039, 0xe6db99e5, 0x1fa27cf8, 0xc4ac5665,
+	0xf4292244, 0x432aff97, 0xab9423a7, 0xfc93a039, 0x655b59c3, 0x8f0ccc92, 0xffeff47d, 0x85845dd1,
+	0x6fa87e4f, 0xfe2ce6e0, 0xa3014314, 0x4e0811a1, 0xf7537e82, 0xbd3af235, 0x2ad7d2bb, 0xeb86d391,
+}
+
+// The left rotations of each round, indexed by the step number mod 4
+var md5Shifts = [4][4]uint32{
+	{7, 12, 17, 22},
+	{5, 9, 14, 20},
+	{4, 11, 16, 23},
+	{6, 10, 15, 21},
+}
+
+// MD5 computes MD5 digests.
+type MD5 struct{}
+
+// Perform an MD5 message digest
+func (md MD5) Digest(message []byte) []byte {
+	d := NewMD5()
+	d.Write(message)
+	return d.Sum(nil)
+}
+
+// NewMD5 returns a new hash.Hash computing the MD5 checksum (RFC 1321).
+func NewMD5() hash.Hash {
+	return newDigest(md5Block)
+}
+
+// Process the 64 byte chunks of the message
+func md5Block(h *[4]uint32, p []byte) {
+	var x [16]uint32
+
+	for len(p) >= BlockSize {
+		for i := range x {
+			x[i] = binary.LittleEndian.Uint32(p[i*4:])
+		}
+
+		a, b, c, d := h[0], h[1], h[2], h[3]
+
+		for i := 0; i < 64; i++ {
+			var f uint32
+			var k int
+
+			switch i / 16 {
+			case 0:
+				f = (b & c) | (^b & d)
+				k = i
+			case 1:
+				f = (b & d) | (c & ^d)
+				k = (5*i + 1) % 16
+			case 2:
+				f = b ^ c ^ d
+				k = (3*i + 5) % 16
+			default:
+				f = c ^ (b | ^d)
+				k = (7 * i) % 16
+			}
+
+			tmp := d
+			d = c
+			c = b
+			b = b + byteutil.Lrot32(a+f+md5T[i]+x[k], md5Shifts[i/16][i%4])
+			a = tmp
+		}
+
+		h[0] += a
+		h[1] += b
+		h[2] += c
+		h[3] += d
+
+		p = p[BlockSize:]
+	}
+}
@@ -0,0 +1,69 @@
+package md
+
+import (
+	"encoding/hex"
+	"hash"
+	"strings"
+	"testing"
+)
+
+// The messages of the RFC 1320 and RFC 1321 test suites
+var mdMessages = []string{
+	"",
+	"a",
+	"abc",
+	"message digest",
+	"abcdefghijklmnopqrstuvwxyz",
+	"ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789",
+	strings.Repeat("1234567890", 8),
+}
+
+var md5Digests = []string{
+	"d41d8cd98f00b204e9800998ecf8427e",
+	"0cc175b9c0f1b6a831c399e269772661",
+	"900150983cd24fb0d6963f7d28e17f72",
+	"f96b697d7cb7938d525a2f31aaf161d0",
+	"c3fcd3d76192e4007dfb496cca67e13b",
+	"d174ab98d277d9f5a5611c2c9f419d9f",
+	"57edf4a22be3c955ac49da2e2107b67a",
+}
+
+var md4Digests = []string{
+	"31d6cfe0d16ae931b73c59d7e0c089c0",
+	"bde52cb31de33e46245e05fbdbd6fb24",
+	"a448017aaf21d8525fc10ae87aa6729d",
+	"d9130a8164549fe818874806e1c7014b",
+	"d79e1c308aa5bbcdeea8ed63df412da9",
+	"043f8582f241db351ce627e153e7f0e4",
+	"e33b4ddc9c38f2199c3e7b164fcc0536",
+}
+
+// Check the test suite both in one Write and by writing the
+// message in uneven pieces
+func testMD(t *testing.T, name string, digest func([]byte) []byte, newHash func() hash.Hash, digests []string) {
+	for i, message := range mdMessages {
+		if computedHex := hex.EncodeToString(digest([]byte(message))); computedHex != digests[i] {
+			t.Errorf("Incorrect %s digest of %q, expected %s got %s", name, message, digests[i], computedHex)
+		}
+
+		d := newHash()
+		for j := 0; j < len(message); j += 7 {
+			end := j + 7
+			if end > len(message) {
+				end = len(message)
+			}
+			d.Write([]byte(message[j:end]))
+		}
+		if computedHex := hex.EncodeToString(d.Sum(nil)); computedHex != digests[i] {
+			t.Errorf("Incorrect streaming %s digest of %q, expected %s got %s", name, message, digests[i], computedHex)
+		}
+	}
+}
+
+func TestMD5(t *testing.T) {
+	testMD(t, "MD5", MD5{}.Digest, NewMD5, md5Digests)
+}
+
+func TestMD4(t *testing.T) {
+	testMD(t, "MD4", MD4{}.Digest, NewMD4, md4Digests)
+}
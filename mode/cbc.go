@@ -0,0 +1,116 @@
+package mode
+
+import "crypto/cipher"
+
+// cbc holds the state shared by the CBC encrypter and decrypter.
+type cbc struct {
+	b   cipher.Block
+	iv  []byte
+	tmp []byte
+}
+
+func newCBC(b cipher.Block, iv []byte) *cbc {
+	c := &cbc{
+		b:   b,
+		iv:  make([]byte, len(iv)),
+		tmp: make([]byte, b.BlockSize()),
+	}
+	copy(c.iv, iv)
+	return c
+}
+
+type cbcEncrypter cbc
+
+// NewCBCEncrypter returns a cipher.BlockMode which encrypts in cipher block
+// chaining mode, the length of the iv must be the same as the block size.
+func NewCBCEncrypter(b cipher.Block, iv []byte) cipher.BlockMode {
+	if len(iv) != b.BlockSize() {
+		panic(ErrInvalidIV)
+	}
+	return (*cbcEncrypter)(newCBC(b, iv))
+}
+
+func (x *cbcEncrypter) BlockSize() int { return x.b.BlockSize() }
+
+func (x *cbcEncrypter) CryptBlocks(dst, src []byte) {
+	bs := x.b.BlockSize()
+	if len(src)%bs != 0 {
+		panic(ErrNotFullBlocks)
+	}
+
+	for len(src) > 0 {
+		// Each plaintext block is XORed with the previous ciphertext block
+		xorBytes(x.tmp, src[:bs], x.iv)
+		x.b.Encrypt(dst[:bs], x.tmp)
+		copy(x.iv, dst[:bs])
+
+		src = src[bs:]
+		dst = dst[bs:]
+	}
+}
+
+type cbcDecrypter cbc
+
+// NewCBCDecrypter returns a cipher.BlockMode which decrypts in cipher block
+// chaining mode, the length of the iv must be the same as the block size.
+func NewCBCDecrypter(b cipher.Block, iv []byte) cipher.BlockMode {
+	if len(iv) != b.BlockSize() {
+		panic(ErrInvalidIV)
+	}
+	return (*cbcDecrypter)(newCBC(b, iv))
+}
+
+func (x *cbcDecrypter) BlockSize() int { return x.b.BlockSize() }
+
+func (x *cbcDecrypter) CryptBlocks(dst, src []byte) {
+	bs := x.b.BlockSize()
+	if len(src)%bs != 0 {
+		panic(ErrNotFullBlocks)
+	}
+
+	next := make([]byte, bs)
+	for len(src) > 0 {
+		// Keep the ciphertext block since dst and src may overlap
+		copy(next, src[:bs])
+		x.b.Decrypt(x.tmp, src[:bs])
+		xorBytes(dst[:bs], x.tmp, x.iv)
+		copy(x.iv, next)
+
+		src = src[bs:]
+		dst = dst[bs:]
+	}
+}
+
+// Encrypt the plaintext in CBC mode with PKCS#7 padding, a random IV
+// is generated and prepended to the returned ciphertext.
+func EncryptCBC(b cipher.Block, plaintext []byte) ([]byte, error) {
+	bs := b.BlockSize()
+	iv, err := RandomIV(bs)
+	if err != nil {
+		return nil, err
+	}
+
+	padded := PKCS7Pad(plaintext, bs)
+	ciphertext := make([]byte, bs+len(padded))
+	copy(ciphertext, iv)
+	NewCBCEncrypter(b, iv).CryptBlocks(ciphertext[bs:], padded)
+
+	return ciphertext, nil
+}
+
+// Decrypt a ciphertext produced by EncryptCBC, the IV is taken from the
+// first block and the PKCS#7 padding is validated and removed.
+func DecryptCBC(b cipher.Block, ciphertext []byte) ([]byte, error) {
+	bs := b.BlockSize()
+	if len(ciphertext) < 2*bs {
+		return nil, ErrShortInput
+	}
+	if len(ciphertext)%bs != 0 {
+		return nil, ErrNotFullBlocks
+	}
+
+	plaintext := make([]byte, len(ciphertext)-bs)
+	NewCBCDecrypter(b, ciphertext[:bs]).CryptBlocks(plaintext, ciphertext[bs:])
+
+	return PKCS7Unpad(plaintext, bs)
+}
@@ -0,0 +1,64 @@
+package mode
+
+import "crypto/cipher"
+
+// cfb is the full-block cipher feedback mode keystream generator,
+// the previous ciphertext block is encrypted to produce the keystream.
+type cfb struct {
+	b       cipher.Block
+	next    []byte
+	out     []byte
+	used    int
+	decrypt bool
+}
+
+func newCFB(b cipher.Block, iv []byte, decrypt bool) cipher.Stream {
+	if len(iv) != b.BlockSize() {
+		panic(ErrInvalidIV)
+	}
+
+	x := &cfb{
+		b:       b,
+		next:    make([]byte, len(iv)),
+		out:     make([]byte, b.BlockSize()),
+		decrypt: decrypt,
+	}
+	copy(x.next, iv)
+	x.used = len(x.out)
+	return x
+}
+
+// NewCFBEncrypter returns a cipher.Stream which encrypts in cipher feedback
+// mode, the length of the iv must be the same as the block size.
+func NewCFBEncrypter(b cipher.Block, iv []byte) cipher.Stream {
+	return newCFB(b, iv, false)
+}
+
+// NewCFBDecrypter returns a cipher.Stream which decrypts in cipher feedback
+// mode, the length of the iv must be the same as the block size.
+func NewCFBDecrypter(b cipher.Block, iv []byte) cipher.Stream {
+	return newCFB(b, iv, true)
+}
+
+func (x *cfb) XORKeyStream(dst, src []byte) {
+	for len(src) > 0 {
+		if x.used == len(x.out) {
+			x.b.Encrypt(x.out, x.next)
+			x.used = 0
+		}
+
+		// The ciphertext is fed back, when decrypting it has to be
+		// kept before dst overwrites src
+		if x.decrypt {
+			copy(x.next[x.used:], src)
+		}
+		n := xorBytes(dst, src, x.out[x.used:])
+		if !x.decrypt {
+			copy(x.next[x.used:], dst[:n])
+		}
+
+		x.used += n
+		dst = dst[n:]
+		src = src[n:]
+	}
+}
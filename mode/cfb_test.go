@@ -0,0 +1,57 @@
+package mode
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+)
+
+// NIST SP 800-38A F.3.13 and F.3.17 vectors (CFB128)
+var cfbVectors = []struct {
+	key, iv, ciphertext string
+}{
+	{
+		"2b7e151628aed2a6abf7158809cf4f3c",
+		"000102030405060708090a0b0c0d0e0f",
+		"3b3fd92eb72dad20333449f8e83cfb4a" +
+			"c8a64537a0b3a93fcde3cdad9f1ce58b" +
+			"26751f67a3cbb140b1808cf187a4f4df" +
+			"c04b05357c5d1c0eeac4c66f9ff7f2e6",
+	},
+	{
+		"603deb1015ca71be2b73aef0857d77811f352c073b6108d72d9810a30914dff4",
+		"000102030405060708090a0b0c0d0e0f",
+		"dc7e84bfda79164b7ecd8486985d3860" +
+			"39ffed143b28b1c832113c6331e5407b" +
+			"df10132415e54b92a13ed0a8267ae2f9" +
+			"75a385741ab9cef82031623d55b1e471",
+	},
+}
+
+func TestCFB(t *testing.T) {
+	plaintext := decodeHex(t, sp80038aPlaintext)
+
+	for _, v := range cfbVectors {
+		block := newAES(t, v.key)
+		iv := decodeHex(t, v.iv)
+
+		dst := make([]byte, len(plaintext))
+		NewCFBEncrypter(block, iv).XORKeyStream(dst, plaintext)
+		if hex.EncodeToString(dst) != v.ciphertext {
+			t.Errorf("Incorrect CFB encryption with key %s, expected %s got %x", v.key, v.ciphertext, dst)
+		}
+
+		// Decrypt in place in uneven pieces
+		stream := NewCFBDecrypter(block, iv)
+		for i := 0; i < len(dst); i += 5 {
+			end := i + 5
+			if end > len(dst) {
+				end = len(dst)
+			}
+			stream.XORKeyStream(dst[i:end], dst[i:end])
+		}
+		if !bytes.Equal(dst, plaintext) {
+			t.Errorf("Incorrect CFB decryption with key %s, got %x", v.key, dst)
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package mode
+
+import (
+	"crypto/cipher"
+	"io"
+)
+
+// ctr is the counter mode keystream generator.
+type ctr struct {
+	b       cipher.Block
+	counter []byte
+	out     []byte
+	used    int
+}
+
+// NewCTR returns a cipher.Stream which encrypts or decrypts using the block
+// cipher in counter mode. The iv is the initial counter block, it is treated
+// as a big-endian integer and incremented for every block of keystream.
+func NewCTR(b cipher.Block, iv []byte) cipher.Stream {
+	if len(iv) != b.BlockSize() {
+		panic(ErrInvalidIV)
+	}
+
+	x := &ctr{
+		b:       b,
+		counter: make([]byte, len(iv)),
+		out:     make([]byte, b.BlockSize()),
+	}
+	copy(x.counter, iv)
+	x.used = len(x.out)
+	return x
+}
+
+// Generate the next block of keystream and increment the counter
+func (x *ctr) refill() {
+	x.b.Encrypt(x.out, x.counter)
+	x.used = 0
+
+	for i := len(x.counter) - 1; i >= 0; i-- {
+		x.counter[i]++
+		if x.counter[i] != 0 {
+			break
+		}
+	}
+}
+
+func (x *ctr) XORKeyStream(dst, src []byte) {
+	for len(src) > 0 {
+		if x.used == len(x.out) {
+			x.refill()
+		}
+		n := xorBytes(dst, src, x.out[x.used:])
+		x.used += n
+		dst = dst[n:]
+		src = src[n:]
+	}
+}
+
+// NewCTRReader wraps the reader so the data read from it is
+// encrypted or decrypted in counter mode.
+func NewCTRReader(b cipher.Block, iv []byte, r io.Reader) io.Reader {
+	return cipher.StreamReader{S: NewCTR(b, iv), R: r}
+}
+
+// NewCTRWriter wraps the writer so the data written to it is
+// encrypted or decrypted in counter mode.
+func NewCTRWriter(b cipher.Block, iv []byte, w io.Writer) io.Writer {
+	return cipher.StreamWriter{S: NewCTR(b, iv), W: w}
+}
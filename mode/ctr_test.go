@@ -0,0 +1,114 @@
+package mode
+
+import (
+	"bytes"
+	"encoding/hex"
+	"io"
+	"io/ioutil"
+	"testing"
+	"testing/iotest"
+)
+
+// NIST SP 800-38A F.5 vectors
+var ctrVectors = []struct {
+	key, iv, ciphertext string
+}{
+	{
+		"2b7e151628aed2a6abf7158809cf4f3c",
+		"f0f1f2f3f4f5f6f7f8f9fafbfcfdfeff",
+		"874d6191b620e3261bef6864990db6ce" +
+			"9806f66b7970fdff8617187bb9fffdff" +
+			"5ae4df3edbd5d35e5b4f09020db03eab" +
+			"1e031dda2fbe03d1792170a0f3009cee",
+	},
+	{
+		"8e73b0f7da0e6452c810f32b809079e562f8ead2522c6b7b",
+		"f0f1f2f3f4f5f6f7f8f9fafbfcfdfeff",
+		"1abc932417521ca24f2b0459fe7e6e0b" +
+			"090339ec0aa6faefd5ccc2c6f4ce8e94" +
+			"1e36b26bd1ebc670d1bd1d665620abf7" +
+			"4f78a7f6d29809585a97daec58c6b050",
+	},
+	{
+		"603deb1015ca71be2b73aef0857d77811f352c073b6108d72d9810a30914dff4",
+		"f0f1f2f3f4f5f6f7f8f9fafbfcfdfeff",
+		"601ec313775789a5b7a7f504bbf3d228" +
+			"f443e3ca4d62b59aca84e990cacaf5c5" +
+			"2b0930daa23de94ce87017ba2d84988d" +
+			"dfc9c58db67aada613c2dd08457941a6",
+	},
+}
+
+func TestCTR(t *testing.T) {
+	plaintext := decodeHex(t, sp80038aPlaintext)
+
+	for _, v := range ctrVectors {
+		block := newAES(t, v.key)
+		iv := decodeHex(t, v.iv)
+
+		dst := make([]byte, len(plaintext))
+		NewCTR(block, iv).XORKeyStream(dst, plaintext)
+		if hex.EncodeToString(dst) != v.ciphertext {
+			t.Errorf("Incorrect CTR encryption with key %s, expected %s got %x", v.key, v.ciphertext, dst)
+		}
+
+		// The keystream must not depend on how the input is split
+		stream := NewCTR(block, iv)
+		for i := 0; i < len(dst); i += 7 {
+			end := i + 7
+			if end > len(dst) {
+				end = len(dst)
+			}
+			stream.XORKeyStream(dst[i:end], dst[i:end])
+		}
+		if !bytes.Equal(dst, plaintext) {
+			t.Errorf("Incorrect CTR decryption with key %s, got %x", v.key, dst)
+		}
+	}
+}
+
+func TestCTRCounterOverflow(t *testing.T) {
+	block := newAES(t, "2b7e151628aed2a6abf7158809cf4f3c")
+	iv := decodeHex(t, "00000000000000000000ffffffffffff")
+	next := decodeHex(t, "00000000000000000001000000000000")
+
+	keystream := make([]byte, 2*len(iv))
+	NewCTR(block, iv).XORKeyStream(keystream, keystream)
+
+	expected := make([]byte, len(next))
+	block.Encrypt(expected, next)
+	if !bytes.Equal(keystream[len(iv):], expected) {
+		t.Errorf("Incorrect counter increment, expected %x got %x", expected, keystream[len(iv):])
+	}
+}
+
+func TestCTRReaderWriter(t *testing.T) {
+	block := newAES(t, "000102030405060708090a0b0c0d0e0f")
+	iv := decodeHex(t, "f0f1f2f3f4f5f6f7f8f9fafbfcfdfeff")
+
+	plaintext := make([]byte, 100000)
+	for i := range plaintext {
+		plaintext[i] = byte(i * 7)
+	}
+
+	var ciphertext bytes.Buffer
+	w := NewCTRWriter(block, iv, &ciphertext)
+	if _, err := io.Copy(w, iotest.HalfReader(bytes.NewReader(plaintext))); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := make([]byte, len(plaintext))
+	NewCTR(block, iv).XORKeyStream(expected, plaintext)
+	if !bytes.Equal(ciphertext.Bytes(), expected) {
+		t.Error("Incorrect CTR writer ciphertext")
+	}
+
+	r := NewCTRReader(block, iv, iotest.OneByteReader(&ciphertext))
+	decrypted, err := ioutil.ReadAll(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(decrypted, plaintext) {
+		t.Error("Incorrect CTR reader plaintext")
+	}
+}
@@ -0,0 +1,77 @@
+package mode
+
+import "crypto/cipher"
+
+// ecb holds the block cipher used by the ECB encrypter and decrypter.
+type ecb struct {
+	b cipher.Block
+}
+
+type ecbEncrypter ecb
+
+// NewECBEncrypter returns a cipher.BlockMode which encrypts every block
+// independently in electronic codebook mode.
+func NewECBEncrypter(b cipher.Block) cipher.BlockMode {
+	return &ecbEncrypter{b}
+}
+
+func (x *ecbEncrypter) BlockSize() int { return x.b.BlockSize() }
+
+func (x *ecbEncrypter) CryptBlocks(dst, src []byte) {
+	bs := x.b.BlockSize()
+	if len(src)%bs != 0 {
+		panic(ErrNotFullBlocks)
+	}
+
+	for len(src) > 0 {
+		x.b.Encrypt(dst[:bs], src[:bs])
+		src = src[bs:]
+		dst = dst[bs:]
+	}
+}
+
+type ecbDecrypter ecb
+
+// NewECBDecrypter returns a cipher.BlockMode which decrypts every block
+// independently in electronic codebook mode.
+func NewECBDecrypter(b cipher.Block) cipher.BlockMode {
+	return &ecbDecrypter{b}
+}
+
+func (x *ecbDecrypter) BlockSize() int { return x.b.BlockSize() }
+
+func (x *ecbDecrypter) CryptBlocks(dst, src []byte) {
+	bs := x.b.BlockSize()
+	if len(src)%bs != 0 {
+		panic(ErrNotFullBlocks)
+	}
+
+	for len(src) > 0 {
+		x.b.Decrypt(dst[:bs], src[:bs])
+		src = src[bs:]
+		dst = dst[bs:]
+	}
+}
+
+// Encrypt the plaintext in ECB mode with PKCS#7 padding. Identical
+// plaintext blocks produce identical ciphertext blocks, ECB should
+// only be used for compatibility.
+func EncryptECB(b cipher.Block, plaintext []byte) []byte {
+	padded := PKCS7Pad(plaintext, b.BlockSize())
+	ciphertext := make([]byte, len(padded))
+	NewECBEncrypter(b).CryptBlocks(ciphertext, padded)
+	return ciphertext
+}
+
+// Decrypt a ciphertext produced by EncryptECB, the PKCS#7 padding
+// is validated and removed.
+func DecryptECB(b cipher.Block, ciphertext []byte) ([]byte, error) {
+	if len(ciphertext)%b.BlockSize() != 0 {
+		return nil, ErrNotFullBlocks
+	}
+
+	plaintext := make([]byte, len(ciphertext))
+	NewECBDecrypter(b).CryptBlocks(plaintext, ciphertext)
+
+	return PKCS7Unpad(plaintext, b.BlockSize())
+}
@@ -0,0 +1,64 @@
+package mode
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+)
+
+// NIST SP 800-38A F.1 vectors
+var ecbVectors = []struct {
+	key, ciphertext string
+}{
+	{
+		"2b7e151628aed2a6abf7158809cf4f3c",
+		"3ad77bb40d7a3660a89ecaf32466ef97" +
+			"f5d3d58503b9699de785895a96fdbaaf" +
+			"43b1cd7f598ece23881b00e3ed030688" +
+			"7b0c785e27e8ad3f8223207104725dd4",
+	},
+	{
+		"603deb1015ca71be2b73aef0857d77811f352c073b6108d72d9810a30914dff4",
+		"f3eed1bdb5d2a03c064b5a7e3db181f8" +
+			"591ccb10d410ed26dc5ba74a31362870" +
+			"b6ed21b99ca6f4f9f153e7b1beafed1d" +
+			"23304b7a39f9f3ff067d8d8f9e24ecc7",
+	},
+}
+
+func TestECB(t *testing.T) {
+	plaintext := decodeHex(t, sp80038aPlaintext)
+
+	for _, v := range ecbVectors {
+		block := newAES(t, v.key)
+
+		dst := make([]byte, len(plaintext))
+		NewECBEncrypter(block).CryptBlocks(dst, plaintext)
+		if hex.EncodeToString(dst) != v.ciphertext {
+			t.Errorf("Incorrect ECB encryption with key %s, expected %s got %x", v.key, v.ciphertext, dst)
+		}
+
+		NewECBDecrypter(block).CryptBlocks(dst, dst)
+		if !bytes.Equal(dst, plaintext) {
+			t.Errorf("Incorrect ECB decryption with key %s, got %x", v.key, dst)
+		}
+	}
+}
+
+func TestECBRoundTrip(t *testing.T) {
+	block := newAES(t, "000102030405060708090a0b0c0d0e0f")
+	plaintext := "My secret message"
+
+	ciphertext := EncryptECB(block, []byte(plaintext))
+	decrypted, err := DecryptECB(block, ciphertext)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(decrypted) != plaintext {
+		t.Errorf("Incorrect round trip, expected \"%s\" got \"%s\"", plaintext, decrypted)
+	}
+
+	if _, err := DecryptECB(block, ciphertext[1:]); err != ErrNotFullBlocks {
+		t.Errorf("Expected ErrNotFullBlocks, got %v", err)
+	}
+}
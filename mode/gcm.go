@@ -0,0 +1,218 @@
+package mode
+
+import (
+	"crypto/cipher"
+	"crypto/subtle"
+	"encoding/binary"
+	"errors"
+)
+
+const (
+	// The GCM nonce size in bytes, only 96-bit nonces are supported.
+	GCMNonceSize = 12
+
+	// The GCM tag size in bytes.
+	GCMTagSize = 16
+
+	gcmBlockSize = 16
+)
+
+var (
+	ErrOpen         = errors.New("cryptster/mode: message authentication failed")
+	ErrGCMBlockSize = errors.New("cryptster/mode: GCM requires a 128-bit block cipher")
+)
+
+// gcmElement is an element of GF(2^128) in the bit-reflected
+// representation used by GCM, hi holds the first 8 bytes.
+type gcmElement struct {
+	hi, lo uint64
+}
+
+// gcm is an instance of the Galois/Counter Mode.
+type gcm struct {
+	b cipher.Block
+	h gcmElement
+}
+
+// NewGCM returns the block cipher wrapped in Galois/Counter Mode as specified
+// by NIST SP 800-38D, with 96-bit nonces and 128-bit tags.
+func NewGCM(b cipher.Block) (cipher.AEAD, error) {
+	if b.BlockSize() != gcmBlockSize {
+		return nil, ErrGCMBlockSize
+	}
+
+	// The hash subkey is the encryption of the zero block
+	var h [gcmBlockSize]byte
+	b.Encrypt(h[:], h[:])
+
+	return &gcm{b: b, h: getElement(h[:])}, nil
+}
+
+func (g *gcm) NonceSize() int { return GCMNonceSize }
+
+func (g *gcm) Overhead() int { return GCMTagSize }
+
+// Encrypt and authenticate the plaintext, the ciphertext and its tag are
+// appended to dst.
+func (g *gcm) Seal(dst, nonce, plaintext, additionalData []byte) []byte {
+	if len(nonce) != GCMNonceSize {
+		panic("cryptster/mode: incorrect nonce length given to GCM")
+	}
+
+	ret, out := sliceForAppend(dst, len(plaintext)+GCMTagSize)
+	ciphertext, tag := out[:len(plaintext)], out[len(plaintext):]
+
+	j0 := g.counter(nonce)
+	g.gctr(ciphertext, plaintext, inc32(j0))
+	g.tag(tag, j0, ciphertext, additionalData)
+
+	return ret
+}
+
+// Authenticate and decrypt the ciphertext, the plaintext is appended to dst.
+// ErrOpen is returned when the tag does not match.
+func (g *gcm) Open(dst, nonce, ciphertext, additionalData []byte) ([]byte, error) {
+	if len(nonce) != GCMNonceSize {
+		panic("cryptster/mode: incorrect nonce length given to GCM")
+	}
+
+	if len(ciphertext) < GCMTagSize {
+		return nil, ErrOpen
+	}
+
+	tag := ciphertext[len(ciphertext)-GCMTagSize:]
+	ciphertext = ciphertext[:len(ciphertext)-GCMTagSize]
+
+	j0 := g.counter(nonce)
+	var expected [GCMTagSize]byte
+	g.tag(expected[:], j0, ciphertext, additionalData)
+
+	if subtle.ConstantTimeCompare(expected[:], tag) != 1 {
+		return nil, ErrOpen
+	}
+
+	ret, out := sliceForAppend(dst, len(ciphertext))
+	g.gctr(out, ciphertext, inc32(j0))
+
+	return ret, nil
+}
+
+// Obtain the pre-counter block J0 for a 96-bit nonce
+func (g *gcm) counter(nonce []byte) []byte {
+	j0 := make([]byte, gcmBlockSize)
+	copy(j0, nonce)
+	j0[gcmBlockSize-1] = 1
+	return j0
+}
+
+// Encrypt src into dst in counter mode, only the last 32 bits
+// of the counter block are incremented
+func (g *gcm) gctr(dst, src, counter []byte) {
+	keystream := make([]byte, gcmBlockSize)
+	for len(src) > 0 {
+		g.b.Encrypt(keystream, counter)
+		counter = inc32(counter)
+
+		n := xorBytes(dst, src, keystream)
+		dst = dst[n:]
+		src = src[n:]
+	}
+}
+
+// Compute the authentication tag over the ciphertext and additional data
+func (g *gcm) tag(dst, j0, ciphertext, additionalData []byte) {
+	var s gcmElement
+	s = g.ghash(s, additionalData)
+	s = g.ghash(s, ciphertext)
+
+	// The last block holds the bit lengths of both inputs
+	var lengths [gcmBlockSize]byte
+	binary.BigEndian.PutUint64(lengths[:8], uint64(len(additionalData))*8)
+	binary.BigEndian.PutUint64(lengths[8:], uint64(len(ciphertext))*8)
+	s = g.ghash(s, lengths[:])
+
+	var encJ0 [gcmBlockSize]byte
+	g.b.Encrypt(encJ0[:], j0)
+
+	binary.BigEndian.PutUint64(dst[:8], s.hi)
+	binary.BigEndian.PutUint64(dst[8:], s.lo)
+	xorBytes(dst, dst, encJ0[:])
+}
+
+// Update the GHASH state with the data, which is padded with
+// zeros up to a multiple of the block size
+func (g *gcm) ghash(y gcmElement, data []byte) gcmElement {
+	for len(data) > 0 {
+		var block [gcmBlockSize]byte
+		n := copy(block[:], data)
+		data = data[n:]
+
+		x := getElement(block[:])
+		y.hi ^= x.hi
+		y.lo ^= x.lo
+		y = gfMul(y, g.h)
+	}
+	return y
+}
+
+// Multiply two elements of GF(2^128) modulo x^128 + x^7 + x^2 + x + 1,
+// this is algorithm 1 of NIST SP 800-38D
+func gfMul(x, y gcmElement) gcmElement {
+	var z gcmElement
+	v := y
+
+	for i := 0; i < 128; i++ {
+		var bit uint64
+		if i < 64 {
+			bit = (x.hi >> uint(63-i)) & 1
+		} else {
+			bit = (x.lo >> uint(127-i)) & 1
+		}
+
+		if bit == 1 {
+			z.hi ^= v.hi
+			z.lo ^= v.lo
+		}
+
+		// Multiply v by x, the reduction polynomial is 0xe1 || 0^120
+		lsb := v.lo & 1
+		v.lo = (v.lo >> 1) | (v.hi << 63)
+		v.hi >>= 1
+		if lsb == 1 {
+			v.hi ^= 0xe1 << 56
+		}
+	}
+
+	return z
+}
+
+// Obtain a field element from a 16 byte block
+func getElement(block []byte) gcmElement {
+	return gcmElement{
+		hi: binary.BigEndian.Uint64(block[:8]),
+		lo: binary.BigEndian.Uint64(block[8:]),
+	}
+}
+
+// Increment the last 32 bits of the counter block modulo 2^32,
+// a new block is returned
+func inc32(counter []byte) []byte {
+	next := make([]byte, len(counter))
+	copy(next, counter)
+	ctr := binary.BigEndian.Uint32(next[len(next)-4:])
+	binary.BigEndian.PutUint32(next[len(next)-4:], ctr+1)
+	return next
+}
+
+// Extend the slice by n bytes, returning the whole slice and the
+// newly added tail
+func sliceForAppend(in []byte, n int) (head, tail []byte) {
+	if total := len(in) + n; cap(in) >= total {
+		head = in[:total]
+	} else {
+		head = make([]byte, total)
+		copy(head, in)
+	}
+	tail = head[len(in):]
+	return
+}
@@ -0,0 +1,124 @@
+package mode
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/Triztian/cryptster/des"
+)
+
+// The AES test cases from "The Galois/Counter Mode of Operation (GCM)",
+// McGrew and Viega, also published with NIST SP 800-38D
+var gcmVectors = []struct {
+	key, nonce, plaintext, ad, ciphertext, tag string
+}{
+	{
+		"00000000000000000000000000000000",
+		"000000000000000000000000",
+		"",
+		"",
+		"",
+		"58e2fccefa7e3061367f1d57a4e7455a",
+	},
+	{
+		"00000000000000000000000000000000",
+		"000000000000000000000000",
+		"00000000000000000000000000000000",
+		"",
+		"0388dace60b6a392f328c2b971b2fe78",
+		"ab6e47d42cec13bdf53a67b21257bddf",
+	},
+	{
+		"feffe9928665731c6d6a8f9467308308",
+		"cafebabefacedbaddecaf888",
+		"d9313225f88406e5a55909c5aff5269a86a7a9531534f7da2e4c303d8a318a72" +
+			"1c3c0c95956809532fcf0e2449a6b525b16aedf5aa0de657ba637b391aafd255",
+		"",
+		"42831ec2217774244b7221b784d0d49ce3aa212f2c02a4e035c17e2329aca12e" +
+			"21d514b25466931c7d8f6a5aac84aa051ba30b396a0aac973d58e091473f5985",
+		"4d5c2af327cd64a62cf35abd2ba6fab4",
+	},
+	{
+		"feffe9928665731c6d6a8f9467308308",
+		"cafebabefacedbaddecaf888",
+		"d9313225f88406e5a55909c5aff5269a86a7a9531534f7da2e4c303d8a318a72" +
+			"1c3c0c95956809532fcf0e2449a6b525b16aedf5aa0de657ba637b39",
+		"feedfacedeadbeeffeedfacedeadbeefabaddad2",
+		"42831ec2217774244b7221b784d0d49ce3aa212f2c02a4e035c17e2329aca12e" +
+			"21d514b25466931c7d8f6a5aac84aa051ba30b396a0aac973d58e091",
+		"5bc94fbc3221a5db94fae95ae7121a47",
+	},
+	{
+		"0000000000000000000000000000000000000000000000000000000000000000",
+		"000000000000000000000000",
+		"",
+		"",
+		"",
+		"530f8afbc74536b9a963b4f1c4cb738b",
+	},
+	{
+		"0000000000000000000000000000000000000000000000000000000000000000",
+		"000000000000000000000000",
+		"00000000000000000000000000000000",
+		"",
+		"cea7403d4d606b6e074ec5d3baf39d18",
+		"d0d1c8a799996bf0265b98b5d48ab919",
+	},
+}
+
+func TestGCM(t *testing.T) {
+	for i, v := range gcmVectors {
+		aead, err := NewGCM(newAES(t, v.key))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		nonce := decodeHex(t, v.nonce)
+		plaintext := decodeHex(t, v.plaintext)
+		ad := decodeHex(t, v.ad)
+		expected := v.ciphertext + v.tag
+
+		sealed := aead.Seal(nil, nonce, plaintext, ad)
+		if hex.EncodeToString(sealed) != expected {
+			t.Errorf("Incorrect GCM seal for test case %d, expected %s got %x", i+1, expected, sealed)
+		}
+
+		opened, err := aead.Open(nil, nonce, sealed, ad)
+		if err != nil {
+			t.Errorf("Could not open test case %d: %v", i+1, err)
+		} else if hex.EncodeToString(opened) != v.plaintext {
+			t.Errorf("Incorrect GCM open for test case %d, expected %s got %x", i+1, v.plaintext, opened)
+		}
+	}
+}
+
+func TestGCMTampering(t *testing.T) {
+	v := gcmVectors[3]
+	aead, _ := NewGCM(newAES(t, v.key))
+	nonce := decodeHex(t, v.nonce)
+	ad := decodeHex(t, v.ad)
+	sealed := aead.Seal(nil, nonce, decodeHex(t, v.plaintext), ad)
+
+	for _, i := range []int{0, len(sealed) / 2, len(sealed) - 1} {
+		tampered := append([]byte{}, sealed...)
+		tampered[i] ^= 0x80
+		if _, err := aead.Open(nil, nonce, tampered, ad); err != ErrOpen {
+			t.Errorf("Expected ErrOpen for tampered byte %d, got %v", i, err)
+		}
+	}
+
+	if _, err := aead.Open(nil, nonce, sealed, []byte("other data")); err != ErrOpen {
+		t.Errorf("Expected ErrOpen for different additional data, got %v", err)
+	}
+
+	if _, err := aead.Open(nil, nonce, sealed[:GCMTagSize-1], ad); err != ErrOpen {
+		t.Errorf("Expected ErrOpen for a short ciphertext, got %v", err)
+	}
+}
+
+func TestGCMBlockSize(t *testing.T) {
+	block, _ := des.NewCipher([]byte("8bytekey"))
+	if _, err := NewGCM(block); err != ErrGCMBlockSize {
+		t.Errorf("Expected ErrGCMBlockSize, got %v", err)
+	}
+}
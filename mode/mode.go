@@ -0,0 +1,37 @@
+// Package mode implements the block cipher modes of operation over
+// any cipher.Block, such as the AES and DES ciphers of cryptster.
+package mode
+
+import (
+	"crypto/rand"
+	"errors"
+	"io"
+)
+
+var (
+	ErrNotFullBlocks  = errors.New("cryptster/mode: input not full blocks")
+	ErrShortInput     = errors.New("cryptster/mode: ciphertext too short")
+	ErrInvalidIV      = errors.New("cryptster/mode: IV length must equal block size")
+	ErrInvalidPadding = errors.New("cryptster/mode: invalid padding")
+)
+
+// Generate a random IV for the given block size
+func RandomIV(blockSize int) ([]byte, error) {
+	iv := make([]byte, blockSize)
+	if _, err := io.ReadFull(rand.Reader, iv); err != nil {
+		return nil, err
+	}
+	return iv, nil
+}
+
+// XOR the bytes of a and b into dst, the shortest length is used
+func xorBytes(dst, a, b []byte) int {
+	n := len(a)
+	if len(b) < n {
+		n = len(b)
+	}
+	for i := 0; i < n; i++ {
+		dst[i] = a[i] ^ b[i]
+	}
+	return n
+}
@@ -0,0 +1,195 @@
+package mode
+
+import (
+	"bytes"
+	"crypto/cipher"
+	"encoding/hex"
+	"testing"
+
+	"github.com/Triztian/cryptster/aes"
+	"github.com/Triztian/cryptster/des"
+)
+
+// NIST SP 800-38A, the plaintext shared by the F.1 - F.5 examples
+const sp80038aPlaintext = "6bc1bee22e409f96e93d7e117393172a" +
+	"ae2d8a571e03ac9c9eb76fac45af8e51" +
+	"30c81c46a35ce411e5fbc1191a0a52ef" +
+	"f69f2445df4f9b17ad2b417be66c3710"
+
+func decodeHex(t *testing.T, s string) []byte {
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+func newAES(t *testing.T, key string) cipher.Block {
+	block, err := aes.NewCipher(decodeHex(t, key))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return block
+}
+
+// NIST SP 800-38A F.2 vectors
+var cbcVectors = []struct {
+	key, iv, ciphertext string
+}{
+	{
+		"2b7e151628aed2a6abf7158809cf4f3c",
+		"000102030405060708090a0b0c0d0e0f",
+		"7649abac8119b246cee98e9b12e9197d" +
+			"5086cb9b507219ee95db113a917678b2" +
+			"73bed6b8e3c1743b7116e69e22229516" +
+			"3ff1caa1681fac09120eca307586e1a7",
+	},
+	{
+		"8e73b0f7da0e6452c810f32b809079e562f8ead2522c6b7b",
+		"000102030405060708090a0b0c0d0e0f",
+		"4f021db243bc633d7178183a9fa071e8" +
+			"b4d9ada9ad7dedf4e5e738763f69145a" +
+			"571b242012fb7ae07fa9baac3df102e0" +
+			"08b0e27988598881d920a9e64f5615cd",
+	},
+	{
+		"603deb1015ca71be2b73aef0857d77811f352c073b6108d72d9810a30914dff4",
+		"000102030405060708090a0b0c0d0e0f",
+		"f58c4c04d6e5f1ba779eabfb5f7bfbd6" +
+			"9cfc4e967edb808d679f777bc6702c7d" +
+			"39f23369a9d9bacfa530e26304231461" +
+			"b2eb05e2c39be9fcda6c19078c6a9d1b",
+	},
+}
+
+func TestCBC(t *testing.T) {
+	plaintext := decodeHex(t, sp80038aPlaintext)
+
+	for _, v := range cbcVectors {
+		block := newAES(t, v.key)
+		iv := decodeHex(t, v.iv)
+
+		dst := make([]byte, len(plaintext))
+		NewCBCEncrypter(block, iv).CryptBlocks(dst, plaintext)
+		if hex.EncodeToString(dst) != v.ciphertext {
+			t.Errorf("Incorrect CBC encryption with key %s, expected %s got %x", v.key, v.ciphertext, dst)
+		}
+
+		// Decrypt in place
+		NewCBCDecrypter(block, iv).CryptBlocks(dst, dst)
+		if !bytes.Equal(dst, plaintext) {
+			t.Errorf("Incorrect CBC decryption with key %s, got %x", v.key, dst)
+		}
+	}
+}
+
+// FIPS 81 Appendix C, DES in CBC mode
+func TestCBCDES(t *testing.T) {
+	var (
+		key        string = "0123456789abcdef"
+		iv         string = "1234567890abcdef"
+		plaintext  string = "Now is the time for all "
+		ciphertext string = "e5c7cdde872bf27c43e934008c389c0f683788499a7c05f6"
+	)
+
+	block, err := des.NewCipher(decodeHex(t, key))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dst := make([]byte, len(plaintext))
+	NewCBCEncrypter(block, decodeHex(t, iv)).CryptBlocks(dst, []byte(plaintext))
+	if hex.EncodeToString(dst) != ciphertext {
+		t.Errorf("Incorrect DES CBC encryption, expected %s got %x", ciphertext, dst)
+	}
+
+	NewCBCDecrypter(block, decodeHex(t, iv)).CryptBlocks(dst, dst)
+	if string(dst) != plaintext {
+		t.Errorf("Incorrect DES CBC decryption, expected \"%s\" got \"%s\"", plaintext, dst)
+	}
+}
+
+func TestCBCRoundTrip(t *testing.T) {
+	aesBlock := newAES(t, "000102030405060708090a0b0c0d0e0f")
+	desBlock, _ := des.NewCipher([]byte("8bytekey"))
+	des3Block, _ := des.NewTripleDESCipher([]byte("123456789012345678901234"))
+
+	for _, block := range []cipher.Block{aesBlock, desBlock, des3Block} {
+		for _, plaintext := range []string{"", "My secret message", "exactly sixteen!"} {
+			ciphertext, err := EncryptCBC(block, []byte(plaintext))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			bs := block.BlockSize()
+			if len(ciphertext)%bs != 0 || len(ciphertext) < 2*bs {
+				t.Errorf("Incorrect ciphertext length %d for \"%s\"", len(ciphertext), plaintext)
+			}
+
+			decrypted, err := DecryptCBC(block, ciphertext)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(decrypted) != plaintext {
+				t.Errorf("Incorrect round trip, expected \"%s\" got \"%s\"", plaintext, decrypted)
+			}
+		}
+	}
+}
+
+func TestCBCRandomIV(t *testing.T) {
+	block := newAES(t, "000102030405060708090a0b0c0d0e0f")
+
+	a, _ := EncryptCBC(block, []byte("message"))
+	b, _ := EncryptCBC(block, []byte("message"))
+	if bytes.Equal(a, b) {
+		t.Error("Encrypting the same message twice produced the same ciphertext")
+	}
+}
+
+func TestCBCInvalidCiphertext(t *testing.T) {
+	block := newAES(t, "000102030405060708090a0b0c0d0e0f")
+
+	if _, err := DecryptCBC(block, make([]byte, aes.BlockSize)); err != ErrShortInput {
+		t.Errorf("Expected ErrShortInput, got %v", err)
+	}
+
+	if _, err := DecryptCBC(block, make([]byte, 2*aes.BlockSize+1)); err != ErrNotFullBlocks {
+		t.Errorf("Expected ErrNotFullBlocks, got %v", err)
+	}
+
+	ciphertext, _ := EncryptCBC(block, []byte("My secret message"))
+	ciphertext[len(ciphertext)-1] ^= 0x01
+	if _, err := DecryptCBC(block, ciphertext); err != ErrInvalidPadding {
+		t.Errorf("Expected ErrInvalidPadding, got %v", err)
+	}
+}
+
+func TestPKCS7(t *testing.T) {
+	var vectors = []struct {
+		data, padded string
+	}{
+		{"", "0808080808080808"},
+		{"01", "0107070707070707"},
+		{"01020304050607", "0102030405060701"},
+		{"0102030405060708", "01020304050607080808080808080808"},
+	}
+
+	for _, v := range vectors {
+		padded := PKCS7Pad(decodeHex(t, v.data), 8)
+		if hex.EncodeToString(padded) != v.padded {
+			t.Errorf("Incorrect padding of %s, expected %s got %x", v.data, v.padded, padded)
+		}
+
+		data, err := PKCS7Unpad(padded, 8)
+		if err != nil || hex.EncodeToString(data) != v.data {
+			t.Errorf("Incorrect unpadding of %s, got %x (%v)", v.padded, data, err)
+		}
+	}
+
+	for _, invalid := range []string{"", "01020304050607", "0102030405060700", "0102030405060709", "0102030405060302"} {
+		if _, err := PKCS7Unpad(decodeHex(t, invalid), 8); err != ErrInvalidPadding {
+			t.Errorf("Expected ErrInvalidPadding for %s, got %v", invalid, err)
+		}
+	}
+}
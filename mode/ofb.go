@@ -0,0 +1,40 @@
+package mode
+
+import "crypto/cipher"
+
+// ofb is the output feedback mode keystream generator, the previous
+// keystream block is encrypted to produce the next one.
+type ofb struct {
+	b    cipher.Block
+	out  []byte
+	used int
+}
+
+// NewOFB returns a cipher.Stream which encrypts or decrypts in output
+// feedback mode, the length of the iv must be the same as the block size.
+func NewOFB(b cipher.Block, iv []byte) cipher.Stream {
+	if len(iv) != b.BlockSize() {
+		panic(ErrInvalidIV)
+	}
+
+	x := &ofb{
+		b:   b,
+		out: make([]byte, len(iv)),
+	}
+	copy(x.out, iv)
+	x.used = len(x.out)
+	return x
+}
+
+func (x *ofb) XORKeyStream(dst, src []byte) {
+	for len(src) > 0 {
+		if x.used == len(x.out) {
+			x.b.Encrypt(x.out, x.out)
+			x.used = 0
+		}
+		n := xorBytes(dst, src, x.out[x.used:])
+		x.used += n
+		dst = dst[n:]
+		src = src[n:]
+	}
+}
@@ -0,0 +1,56 @@
+package mode
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+)
+
+// NIST SP 800-38A F.4.1 and F.4.5 vectors
+var ofbVectors = []struct {
+	key, iv, ciphertext string
+}{
+	{
+		"2b7e151628aed2a6abf7158809cf4f3c",
+		"000102030405060708090a0b0c0d0e0f",
+		"3b3fd92eb72dad20333449f8e83cfb4a" +
+			"7789508d16918f03f53c52dac54ed825" +
+			"9740051e9c5fecf64344f7a82260edcc" +
+			"304c6528f659c77866a510d9c1d6ae5e",
+	},
+	{
+		"603deb1015ca71be2b73aef0857d77811f352c073b6108d72d9810a30914dff4",
+		"000102030405060708090a0b0c0d0e0f",
+		"dc7e84bfda79164b7ecd8486985d3860" +
+			"4febdc6740d20b3ac88f6ad82a4fb08d" +
+			"71ab47a086e86eedf39d1c5bba97c408" +
+			"0126141d67f37be8538f5a8be740e484",
+	},
+}
+
+func TestOFB(t *testing.T) {
+	plaintext := decodeHex(t, sp80038aPlaintext)
+
+	for _, v := range ofbVectors {
+		block := newAES(t, v.key)
+		iv := decodeHex(t, v.iv)
+
+		dst := make([]byte, len(plaintext))
+		NewOFB(block, iv).XORKeyStream(dst, plaintext)
+		if hex.EncodeToString(dst) != v.ciphertext {
+			t.Errorf("Incorrect OFB encryption with key %s, expected %s got %x", v.key, v.ciphertext, dst)
+		}
+
+		stream := NewOFB(block, iv)
+		for i := 0; i < len(dst); i += 3 {
+			end := i + 3
+			if end > len(dst) {
+				end = len(dst)
+			}
+			stream.XORKeyStream(dst[i:end], dst[i:end])
+		}
+		if !bytes.Equal(dst, plaintext) {
+			t.Errorf("Incorrect OFB decryption with key %s, got %x", v.key, dst)
+		}
+	}
+}
@@ -0,0 +1,34 @@
+package mode
+
+// Pad the data with PKCS#7 up to a multiple of the block size; a full
+// block of padding is added when the data is already aligned.
+func PKCS7Pad(data []byte, blockSize int) []byte {
+	n := blockSize - len(data)%blockSize
+	padded := make([]byte, len(data), len(data)+n)
+	copy(padded, data)
+	for i := 0; i < n; i++ {
+		padded = append(padded, byte(n))
+	}
+	return padded
+}
+
+// Remove the PKCS#7 padding from the data, every padding byte is
+// checked so any tampering results in ErrInvalidPadding.
+func PKCS7Unpad(data []byte, blockSize int) ([]byte, error) {
+	if len(data) == 0 || len(data)%blockSize != 0 {
+		return nil, ErrInvalidPadding
+	}
+
+	n := int(data[len(data)-1])
+	if n == 0 || n > blockSize {
+		return nil, ErrInvalidPadding
+	}
+
+	for _, b := range data[len(data)-n:] {
+		if int(b) != n {
+			return nil, ErrInvalidPadding
+		}
+	}
+
+	return data[:len(data)-n], nil
+}
@@ -0,0 +1,125 @@
+package cryptster
+
+import (
+	"bytes"
+	"crypto/cipher"
+	"errors"
+	"io"
+	"io/ioutil"
+
+	"github.com/Triztian/cryptster/mode"
+)
+
+// The block cipher modes of operation accepted by Crypt
+const (
+	ModeECB = "ECB"
+	ModeCBC = "CBC"
+	ModeCFB = "CFB"
+	ModeOFB = "OFB"
+	ModeCTR = "CTR"
+)
+
+var ErrShortInput = errors.New("cryptster: input is shorter than the IV")
+
+type UnknownModeError string
+
+func (m UnknownModeError) Error() string {
+	return "cryptster: unknown mode " + string(m)
+}
+
+// Encrypt or decrypt the data from src into dst using the block cipher
+// in the given mode. ECB and CBC read the whole input and use PKCS#7
+// padding, the stream modes (CFB, OFB and CTR) use constant memory.
+// Every mode but ECB writes a random IV before the ciphertext and
+// reads it from the start of src when decrypting.
+func Crypt(dst io.Writer, src io.Reader, block cipher.Block, blockMode string, decrypt bool) error {
+	switch blockMode {
+	case ModeECB, ModeCBC:
+		result, err := cryptAll(src, block, blockMode, decrypt)
+		if err != nil {
+			return err
+		}
+		_, err = dst.Write(result)
+		return err
+
+	case ModeCFB, ModeOFB, ModeCTR:
+		return cryptStream(dst, src, block, blockMode, decrypt)
+
+	default:
+		return UnknownModeError(blockMode)
+	}
+}
+
+// Encrypt or decrypt the data from the reader using the block cipher in
+// the given block mode and return the result.
+func cryptAll(reader io.Reader, block cipher.Block, blockMode string, decrypt bool) ([]byte, error) {
+	data, err := ioutil.ReadAll(reader)
+	if err != nil {
+		return nil, err
+	}
+
+	switch blockMode {
+	case ModeECB:
+		if decrypt {
+			return mode.DecryptECB(block, data)
+		}
+		return mode.EncryptECB(block, data), nil
+
+	case ModeCBC:
+		if decrypt {
+			return mode.DecryptCBC(block, data)
+		}
+		return mode.EncryptCBC(block, data)
+
+	default:
+		var result bytes.Buffer
+		err = cryptStream(&result, bytes.NewReader(data), block, blockMode, decrypt)
+		return result.Bytes(), err
+	}
+}
+
+// Stream the data from src into dst using the block cipher in the given
+// stream mode; the IV is written to dst or read from src.
+func cryptStream(dst io.Writer, src io.Reader, block cipher.Block, blockMode string, decrypt bool) error {
+	var (
+		iv     []byte
+		stream cipher.Stream
+		err    error
+	)
+
+	if decrypt {
+		iv = make([]byte, block.BlockSize())
+		if _, err = io.ReadFull(src, iv); err != nil {
+			if err == io.EOF || err == io.ErrUnexpectedEOF {
+				return ErrShortInput
+			}
+			return err
+		}
+
+	} else {
+		if iv, err = mode.RandomIV(block.BlockSize()); err != nil {
+			return err
+		}
+		if _, err = dst.Write(iv); err != nil {
+			return err
+		}
+	}
+
+	switch blockMode {
+	case ModeCFB:
+		if decrypt {
+			stream = mode.NewCFBDecrypter(block, iv)
+		} else {
+			stream = mode.NewCFBEncrypter(block, iv)
+		}
+	case ModeOFB:
+		stream = mode.NewOFB(block, iv)
+	case ModeCTR:
+		stream = mode.NewCTR(block, iv)
+	default:
+		return UnknownModeError(blockMode)
+	}
+
+	_, err = io.Copy(cipher.StreamWriter{S: stream, W: dst}, src)
+	return err
+}
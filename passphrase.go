@@ -0,0 +1,72 @@
+package cryptster
+
+import (
+	"crypto/rand"
+	"errors"
+	"io"
+
+	"github.com/Triztian/cryptster/byteutil"
+	"github.com/Triztian/cryptster/kdf"
+	"github.com/Triztian/cryptster/sha"
+)
+
+const (
+	// The size of the random salt of a passphrase in bytes.
+	SaltSize = 16
+
+	// The PBKDF2 iteration count of new passphrase headers.
+	PBKDF2Iterations = 100000
+
+	// The largest iteration count accepted from a header, it bounds the
+	// work done for a corrupt or malicious header.
+	maxPBKDF2Iterations = 10000000
+)
+
+// ErrInvalidHeader is returned when the passphrase header of a
+// ciphertext is too short or has an invalid iteration count.
+var ErrInvalidHeader = errors.New("cryptster: invalid passphrase header")
+
+// A PassphraseHeader holds the PBKDF2 parameters used to derive the key
+// of a ciphertext from a passphrase, it is stored before the ciphertext
+// as the salt followed by the iteration count (Big-Endian uint32).
+type PassphraseHeader struct {
+	Salt       []byte
+	Iterations int
+}
+
+// Create a header with a random salt and the default iteration count
+func NewPassphraseHeader() (*PassphraseHeader, error) {
+	salt := make([]byte, SaltSize)
+	if _, err := io.ReadFull(rand.Reader, salt); err != nil {
+		return nil, err
+	}
+	return &PassphraseHeader{Salt: salt, Iterations: PBKDF2Iterations}, nil
+}
+
+// Read the header that precedes a passphrase encrypted ciphertext
+func ReadPassphraseHeader(reader io.Reader) (*PassphraseHeader, error) {
+	buf := make([]byte, SaltSize+4)
+	if _, err := io.ReadFull(reader, buf); err != nil {
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			return nil, ErrInvalidHeader
+		}
+		return nil, err
+	}
+
+	iterations := int(byteutil.GetInt32(buf[SaltSize:]))
+	if iterations < 1 || iterations > maxPBKDF2Iterations {
+		return nil, ErrInvalidHeader
+	}
+	return &PassphraseHeader{Salt: buf[:SaltSize], Iterations: iterations}, nil
+}
+
+// Write the header, it must precede the ciphertext
+func (h *PassphraseHeader) WriteTo(writer io.Writer) (int64, error) {
+	n, err := writer.Write(append(append([]byte{}, h.Salt...), byteutil.GetBytes32(uint32(h.Iterations))...))
+	return int64(n), err
+}
+
+// Derive a key of keyLen bytes from the passphrase with PBKDF2-HMAC-SHA-256
+func (h *PassphraseHeader) Key(passphrase []byte, keyLen int) []byte {
+	return kdf.PBKDF2(passphrase, h.Salt, h.Iterations, keyLen, sha.NewSHA256)
+}
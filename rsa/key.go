@@ -0,0 +1,153 @@
+package rsa
+
+import (
+	"crypto/rand"
+	"io"
+	"math/big"
+)
+
+// The smallest key size accepted by GenerateKey in bits.
+const MinKeySize = 512
+
+// The odd primes below 100, candidates divisible by them are
+// discarded before the Miller-Rabin test
+var smallPrimes = []int64{
+	3, 5, 7, 11, 13, 17, 19, 23, 29, 31, 37, 41, 43, 47,
+	53, 59, 61, 67, 71, 73, 79, 83, 89, 97,
+}
+
+// Determine if n is a probable prime with the given number of
+// Miller-Rabin rounds, the bases are chosen with the random reader.
+// A composite passes a round with a probability of at most 1/4.
+func ProbablyPrime(n *big.Int, rounds int, random io.Reader) (bool, error) {
+	if n.Cmp(TWO) < 0 {
+		return false, nil
+	}
+	if n.Bit(0) == 0 {
+		return n.Cmp(TWO) == 0, nil
+	}
+
+	mod := new(big.Int)
+	for _, p := range smallPrimes {
+		bp := big.NewInt(p)
+		if n.Cmp(bp) == 0 {
+			return true, nil
+		}
+		if mod.Mod(n, bp).Sign() == 0 {
+			return false, nil
+		}
+	}
+
+	// n - 1 = d * 2^s with d odd
+	nm1 := new(big.Int).Sub(n, ONE)
+	s := nm1.TrailingZeroBits()
+	d := new(big.Int).Rsh(nm1, s)
+
+	// The bases are in [2, n - 2]
+	nm3 := new(big.Int).Sub(n, big.NewInt(3))
+
+	for i := 0; i < rounds; i++ {
+		a, err := rand.Int(random, nm3)
+		if err != nil {
+			return false, err
+		}
+		a.Add(a, TWO)
+
+		x := new(big.Int).Exp(a, d, n)
+		if x.Cmp(ONE) == 0 || x.Cmp(nm1) == 0 {
+			continue
+		}
+
+		composite := true
+		for r := uint(1); r < s; r++ {
+			x.Mul(x, x).Mod(x, n)
+			if x.Cmp(nm1) == 0 {
+				composite = false
+				break
+			}
+		}
+		if composite {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+// Generate a random probable prime of exactly the given number of bits,
+// the two most significant bits are set so the product of two such
+// primes has twice the bits.
+func RandomPrime(random io.Reader, bits int) (*big.Int, error) {
+	if bits < 2 {
+		return nil, KeySizeError(bits)
+	}
+
+	b := make([]byte, (bits+7)/8)
+	p := new(big.Int)
+
+	for {
+		if _, err := io.ReadFull(random, b); err != nil {
+			return nil, err
+		}
+
+		p.SetBytes(b)
+		p.Rsh(p, uint(len(b)*8-bits))
+		p.SetBit(p, bits-1, 1)
+		p.SetBit(p, bits-2, 1)
+		p.SetBit(p, 0, 1)
+
+		prime, err := ProbablyPrime(p, MILLER_RABIN_COUNT, random)
+		if err != nil {
+			return nil, err
+		}
+		if prime {
+			return p, nil
+		}
+	}
+}
+
+// Generate an RSA key pair with a modulus of the given number of bits,
+// the public exponent is 65537 and the private exponent its inverse
+// modulo lcm(p - 1, q - 1).
+func GenerateKey(random io.Reader, bits int) (*PrivateKey, error) {
+	if bits < MinKeySize {
+		return nil, KeySizeError(bits)
+	}
+
+	e := big.NewInt(PublicExponent)
+
+	for {
+		p, err := RandomPrime(random, bits-bits/2)
+		if err != nil {
+			return nil, err
+		}
+		q, err := RandomPrime(random, bits/2)
+		if err != nil {
+			return nil, err
+		}
+		if p.Cmp(q) == 0 {
+			continue
+		}
+
+		pm1 := new(big.Int).Sub(p, ONE)
+		qm1 := new(big.Int).Sub(q, ONE)
+
+		// lcm(p - 1, q - 1) = (p - 1)(q - 1) / gcd(p - 1, q - 1)
+		gcd := new(big.Int).GCD(nil, nil, pm1, qm1)
+		lambda := new(big.Int).Mul(pm1, qm1)
+		lambda.Div(lambda, gcd)
+
+		// e has no inverse if it divides p - 1 or q - 1
+		d := new(big.Int).ModInverse(e, lambda)
+		if d == nil {
+			continue
+		}
+
+		return &PrivateKey{
+			PublicKey: PublicKey{N: new(big.Int).Mul(p, q), E: PublicExponent},
+			D:         d,
+			P:         p,
+			Q:         q,
+		}, nil
+	}
+}
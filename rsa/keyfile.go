@@ -0,0 +1,233 @@
+package rsa
+
+import (
+	"encoding/asn1"
+	"encoding/pem"
+	"errors"
+	"math/big"
+)
+
+var ErrInvalidKey = errors.New("cryptster/rsa: invalid key file")
+
+// The formats of the keys written by MarshalPublicKey and MarshalPrivateKey.
+type KeyFormat int
+
+const (
+	// SubjectPublicKeyInfo public keys ("PUBLIC KEY" PEM blocks) and
+	// PKCS#8 private keys ("PRIVATE KEY" PEM blocks).
+	PKCS8 KeyFormat = iota
+
+	// PKCS#1 keys ("RSA PUBLIC KEY" and "RSA PRIVATE KEY" PEM blocks).
+	PKCS1
+)
+
+// The PEM block types of the key formats
+const (
+	pemPublicKey     = "PUBLIC KEY"
+	pemRSAPublicKey  = "RSA PUBLIC KEY"
+	pemPrivateKey    = "PRIVATE KEY"
+	pemRSAPrivateKey = "RSA PRIVATE KEY"
+)
+
+// The rsaEncryption algorithm identifier of PKCS#8 and SubjectPublicKeyInfo
+var oidRSAEncryption = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 1, 1}
+
+// RSAPublicKey of PKCS#1
+type pkcs1PublicKey struct {
+	N *big.Int
+	E int
+}
+
+// RSAPrivateKey of PKCS#1, only two prime keys (version 0) are supported
+type pkcs1PrivateKey struct {
+	Version int
+	N       *big.Int
+	E       int
+	D       *big.Int
+	P       *big.Int
+	Q       *big.Int
+	Dp      *big.Int
+	Dq      *big.Int
+	Qinv    *big.Int
+}
+
+type algorithmIdentifier struct {
+	Algorithm  asn1.ObjectIdentifier
+	Parameters asn1.RawValue `asn1:"optional"`
+}
+
+type subjectPublicKeyInfo struct {
+	Algorithm algorithmIdentifier
+	PublicKey asn1.BitString
+}
+
+type pkcs8PrivateKey struct {
+	Version    int
+	Algorithm  algorithmIdentifier
+	PrivateKey []byte
+	Attributes []asn1.RawValue `asn1:"optional,tag:0"`
+}
+
+// Encode the public key as a PEM block in the given format
+func MarshalPublicKey(pub *PublicKey, format KeyFormat) []byte {
+	if format == PKCS1 {
+		return pem.EncodeToMemory(&pem.Block{Type: pemRSAPublicKey, Bytes: MarshalPKCS1PublicKey(pub)})
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: pemPublicKey, Bytes: MarshalPKIXPublicKey(pub)})
+}
+
+// Encode the private key as a PEM block in the given format
+func MarshalPrivateKey(priv *PrivateKey, format KeyFormat) []byte {
+	if format == PKCS1 {
+		return pem.EncodeToMemory(&pem.Block{Type: pemRSAPrivateKey, Bytes: MarshalPKCS1PrivateKey(priv)})
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: pemPrivateKey, Bytes: MarshalPKCS8PrivateKey(priv)})
+}
+
+// Parse the first PEM block of data as a public key in any of the
+// formats, the public part of a private key is also accepted
+func ParsePublicKey(data []byte) (*PublicKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, ErrInvalidKey
+	}
+
+	switch block.Type {
+	case pemPublicKey:
+		return ParsePKIXPublicKey(block.Bytes)
+	case pemRSAPublicKey:
+		return ParsePKCS1PublicKey(block.Bytes)
+	case pemPrivateKey, pemRSAPrivateKey:
+		priv, err := ParsePrivateKey(data)
+		if err != nil {
+			return nil, err
+		}
+		return &priv.PublicKey, nil
+	}
+	return nil, ErrInvalidKey
+}
+
+// Parse the first PEM block of data as a PKCS#8 or PKCS#1 private key,
+// encrypted keys are not supported
+func ParsePrivateKey(data []byte) (*PrivateKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, ErrInvalidKey
+	}
+
+	switch block.Type {
+	case pemPrivateKey:
+		return ParsePKCS8PrivateKey(block.Bytes)
+	case pemRSAPrivateKey:
+		return ParsePKCS1PrivateKey(block.Bytes)
+	}
+	return nil, ErrInvalidKey
+}
+
+// Obtain the PKCS#1 DER encoding of the public key
+func MarshalPKCS1PublicKey(pub *PublicKey) []byte {
+	der, _ := asn1.Marshal(pkcs1PublicKey{N: pub.N, E: pub.E})
+	return der
+}
+
+// Parse a PKCS#1 DER encoded public key
+func ParsePKCS1PublicKey(der []byte) (*PublicKey, error) {
+	var key pkcs1PublicKey
+	if rest, err := asn1.Unmarshal(der, &key); err != nil || len(rest) > 0 {
+		return nil, ErrInvalidKey
+	}
+
+	pub := &PublicKey{N: key.N, E: key.E}
+	if !validPublicKey(pub) {
+		return nil, ErrInvalidKey
+	}
+	return pub, nil
+}
+
+// Obtain the PKCS#1 DER encoding of the private key, the CRT values
+// are computed from the primes
+func MarshalPKCS1PrivateKey(priv *PrivateKey) []byte {
+	pm1 := new(big.Int).Sub(priv.P, ONE)
+	qm1 := new(big.Int).Sub(priv.Q, ONE)
+
+	der, _ := asn1.Marshal(pkcs1PrivateKey{
+		N:    priv.N,
+		E:    priv.E,
+		D:    priv.D,
+		P:    priv.P,
+		Q:    priv.Q,
+		Dp:   new(big.Int).Mod(priv.D, pm1),
+		Dq:   new(big.Int).Mod(priv.D, qm1),
+		Qinv: new(big.Int).ModInverse(priv.Q, priv.P),
+	})
+	return der
+}
+
+// Parse a PKCS#1 DER encoded private key
+func ParsePKCS1PrivateKey(der []byte) (*PrivateKey, error) {
+	var key pkcs1PrivateKey
+	if rest, err := asn1.Unmarshal(der, &key); err != nil || len(rest) > 0 || key.Version != 0 {
+		return nil, ErrInvalidKey
+	}
+
+	priv := &PrivateKey{
+		PublicKey: PublicKey{N: key.N, E: key.E},
+		D:         key.D,
+		P:         key.P,
+		Q:         key.Q,
+	}
+	if !validPublicKey(&priv.PublicKey) || priv.D.Sign() <= 0 ||
+		priv.P.Cmp(ONE) <= 0 || priv.Q.Cmp(ONE) <= 0 ||
+		new(big.Int).Mul(priv.P, priv.Q).Cmp(priv.N) != 0 {
+		return nil, ErrInvalidKey
+	}
+	return priv, nil
+}
+
+// Obtain the SubjectPublicKeyInfo DER encoding of the public key
+func MarshalPKIXPublicKey(pub *PublicKey) []byte {
+	pkcs1 := MarshalPKCS1PublicKey(pub)
+	der, _ := asn1.Marshal(subjectPublicKeyInfo{
+		Algorithm: algorithmIdentifier{Algorithm: oidRSAEncryption, Parameters: asn1.NullRawValue},
+		PublicKey: asn1.BitString{Bytes: pkcs1, BitLength: len(pkcs1) * 8},
+	})
+	return der
+}
+
+// Parse a SubjectPublicKeyInfo DER encoded RSA public key
+func ParsePKIXPublicKey(der []byte) (*PublicKey, error) {
+	var info subjectPublicKeyInfo
+	if rest, err := asn1.Unmarshal(der, &info); err != nil || len(rest) > 0 {
+		return nil, ErrInvalidKey
+	}
+	if !info.Algorithm.Algorithm.Equal(oidRSAEncryption) {
+		return nil, ErrInvalidKey
+	}
+	return ParsePKCS1PublicKey(info.PublicKey.RightAlign())
+}
+
+// Obtain the PKCS#8 DER encoding of the private key
+func MarshalPKCS8PrivateKey(priv *PrivateKey) []byte {
+	der, _ := asn1.Marshal(pkcs8PrivateKey{
+		Algorithm:  algorithmIdentifier{Algorithm: oidRSAEncryption, Parameters: asn1.NullRawValue},
+		PrivateKey: MarshalPKCS1PrivateKey(priv),
+	})
+	return der
+}
+
+// Parse a PKCS#8 DER encoded RSA private key
+func ParsePKCS8PrivateKey(der []byte) (*PrivateKey, error) {
+	var key pkcs8PrivateKey
+	if rest, err := asn1.Unmarshal(der, &key); err != nil || len(rest) > 0 {
+		return nil, ErrInvalidKey
+	}
+	if !key.Algorithm.Algorithm.Equal(oidRSAEncryption) {
+		return nil, ErrInvalidKey
+	}
+	return ParsePKCS1PrivateKey(key.PrivateKey)
+}
+
+// Determine if the modulus and public exponent are usable
+func validPublicKey(pub *PublicKey) bool {
+	return pub.N != nil && pub.N.Sign() > 0 && pub.E >= 3 && pub.E <= 1<<31-1
+}
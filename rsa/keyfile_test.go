@@ -0,0 +1,110 @@
+package rsa
+
+import (
+	"crypto/rand"
+	stdrsa "crypto/rsa"
+	"crypto/x509"
+	"testing"
+)
+
+func TestKeyFile(t *testing.T) {
+	priv, err := GenerateKey(rand.Reader, 512)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, format := range []KeyFormat{PKCS8, PKCS1} {
+		parsed, err := ParsePrivateKey(MarshalPrivateKey(priv, format))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if parsed.N.Cmp(priv.N) != 0 || parsed.E != priv.E || parsed.D.Cmp(priv.D) != 0 ||
+			parsed.P.Cmp(priv.P) != 0 || parsed.Q.Cmp(priv.Q) != 0 {
+			t.Errorf("Incorrect parsed private key of format %d", format)
+		}
+
+		pub, err := ParsePublicKey(MarshalPublicKey(&priv.PublicKey, format))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if pub.N.Cmp(priv.N) != 0 || pub.E != priv.E {
+			t.Errorf("Incorrect parsed public key of format %d", format)
+		}
+
+		// The public part of a private key
+		pub, err = ParsePublicKey(MarshalPrivateKey(priv, format))
+		if err != nil || pub.N.Cmp(priv.N) != 0 {
+			t.Errorf("Incorrect public key of a private key of format %d, %v", format, err)
+		}
+
+		if _, err := ParsePrivateKey(MarshalPublicKey(pub, format)); err != ErrInvalidKey {
+			t.Errorf("Expected ErrInvalidKey for a public key, got %v", err)
+		}
+	}
+
+	if _, err := ParsePublicKey([]byte("n 1234\ne 3\n")); err != ErrInvalidKey {
+		t.Errorf("Expected ErrInvalidKey without a PEM block, got %v", err)
+	}
+}
+
+// The DER encodings must be interchangeable with the ones of crypto/x509
+func TestKeyFileInterop(t *testing.T) {
+	priv, err := GenerateKey(rand.Reader, 1024)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	key1, err := x509.ParsePKCS1PrivateKey(MarshalPKCS1PrivateKey(priv))
+	if err != nil {
+		t.Fatal(err)
+	}
+	key8, err := x509.ParsePKCS8PrivateKey(MarshalPKCS8PrivateKey(priv))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, key := range []*stdrsa.PrivateKey{key1, key8.(*stdrsa.PrivateKey)} {
+		if err := key.Validate(); err != nil {
+			t.Errorf("Invalid private key: %v", err)
+		}
+		if key.N.Cmp(priv.N) != 0 || key.D.Cmp(priv.D) != 0 {
+			t.Error("Incorrect private key parsed by crypto/x509")
+		}
+	}
+
+	pub, err := x509.ParsePKIXPublicKey(MarshalPKIXPublicKey(&priv.PublicKey))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pub.(*stdrsa.PublicKey).N.Cmp(priv.N) != 0 {
+		t.Error("Incorrect public key parsed by crypto/x509")
+	}
+
+	std, err := stdrsa.GenerateKey(rand.Reader, 1024)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	der8, _ := x509.MarshalPKCS8PrivateKey(std)
+	parsed1, err := ParsePKCS1PrivateKey(x509.MarshalPKCS1PrivateKey(std))
+	if err != nil {
+		t.Fatal(err)
+	}
+	parsed8, err := ParsePKCS8PrivateKey(der8)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, key := range []*PrivateKey{parsed1, parsed8} {
+		if key.N.Cmp(std.N) != 0 || key.D.Cmp(std.D) != 0 || key.E != std.E {
+			t.Error("Incorrect private key of crypto/x509")
+		}
+	}
+
+	derPub, _ := x509.MarshalPKIXPublicKey(&std.PublicKey)
+	parsedPub, err := ParsePKIXPublicKey(derPub)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if parsedPub.N.Cmp(std.N) != 0 {
+		t.Error("Incorrect public key of crypto/x509")
+	}
+}
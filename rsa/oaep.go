@@ -0,0 +1,125 @@
+package rsa
+
+import (
+	"crypto/subtle"
+	"hash"
+	"io"
+	"math/big"
+
+	"github.com/Triztian/cryptster/byteutil"
+)
+
+// MGF1 is the mask generation function of RFC 8017, it returns length
+// bytes of the hashes of the seed followed by a 32 bit counter.
+func MGF1(newHash func() hash.Hash, seed []byte, length int) []byte {
+	h := newHash()
+	mask := make([]byte, 0, length+h.Size())
+
+	for counter := uint32(0); len(mask) < length; counter++ {
+		h.Reset()
+		h.Write(seed)
+		h.Write(byteutil.GetBytes32(counter))
+		mask = h.Sum(mask)
+	}
+	return mask[:length]
+}
+
+// XOR the mask generated from the seed into out
+func xorMGF1(newHash func() hash.Hash, out, seed []byte) {
+	mask := MGF1(newHash, seed, len(out))
+	for i := range out {
+		out[i] ^= mask[i]
+	}
+}
+
+// Obtain the maximum length of a message that EncryptOAEP accepts
+// for a key with a modulus of k bytes
+func MaxOAEPMessageLength(newHash func() hash.Hash, k int) int {
+	return k - 2*newHash().Size() - 2
+}
+
+// EncryptOAEP encrypts the message with RSAES-OAEP (RFC 8017), the same
+// hash is used for the label and MGF1. The random seed is read from random
+// so encrypting the same message twice produces different ciphertexts.
+// The message can be at most k - 2 * hLen - 2 bytes long for a modulus of
+// k bytes and a hash of hLen bytes.
+func EncryptOAEP(newHash func() hash.Hash, random io.Reader, pub *PublicKey, msg, label []byte) ([]byte, error) {
+	h := newHash()
+	hLen := h.Size()
+	k := pub.Size()
+	if len(msg) > MaxOAEPMessageLength(newHash, k) {
+		return nil, ErrMessageTooLong
+	}
+
+	h.Write(label)
+	lHash := h.Sum(nil)
+
+	// EM = 0x00 || maskedSeed || maskedDB
+	// DB = lHash || PS || 0x01 || M
+	em := make([]byte, k)
+	seed := em[1 : 1+hLen]
+	db := em[1+hLen:]
+
+	copy(db, lHash)
+	db[len(db)-len(msg)-1] = 0x01
+	copy(db[len(db)-len(msg):], msg)
+
+	if _, err := io.ReadFull(random, seed); err != nil {
+		return nil, err
+	}
+
+	xorMGF1(newHash, db, seed)
+	xorMGF1(newHash, seed, db)
+
+	c := encrypt(pub, new(big.Int).SetBytes(em))
+	return i2osp(c, k), nil
+}
+
+// DecryptOAEP decrypts a ciphertext produced by EncryptOAEP with the same
+// hash and label. Every decoding failure returns ErrDecryption and the
+// padding is checked in constant time, so the cause of a failure is not
+// revealed to an attacker.
+func DecryptOAEP(newHash func() hash.Hash, priv *PrivateKey, ciphertext, label []byte) ([]byte, error) {
+	h := newHash()
+	hLen := h.Size()
+	k := priv.Size()
+	if len(ciphertext) != k || k < 2*hLen+2 {
+		return nil, ErrDecryption
+	}
+
+	m, err := decrypt(priv, new(big.Int).SetBytes(ciphertext))
+	if err != nil {
+		return nil, err
+	}
+
+	h.Write(label)
+	lHash := h.Sum(nil)
+
+	em := i2osp(m, k)
+	seed := em[1 : 1+hLen]
+	db := em[1+hLen:]
+
+	xorMGF1(newHash, seed, db)
+	xorMGF1(newHash, db, seed)
+
+	good := subtle.ConstantTimeByteEq(em[0], 0)
+	good &= subtle.ConstantTimeCompare(db[:hLen], lHash)
+
+	// Find the 0x01 separator after PS without branching on the
+	// content of the block, any other non zero byte is invalid
+	var lookingForIndex, index, invalid int
+	lookingForIndex = 1
+	rest := db[hLen:]
+	for i := range rest {
+		isZero := subtle.ConstantTimeByteEq(rest[i], 0)
+		isOne := subtle.ConstantTimeByteEq(rest[i], 1)
+		index = subtle.ConstantTimeSelect(lookingForIndex&isOne, i, index)
+		lookingForIndex = subtle.ConstantTimeSelect(isOne, 0, lookingForIndex)
+		invalid = subtle.ConstantTimeSelect(lookingForIndex&^isZero, 1, invalid)
+	}
+
+	if good&^invalid&^lookingForIndex != 1 {
+		return nil, ErrDecryption
+	}
+	return rest[index+1:], nil
+}
@@ -0,0 +1,173 @@
+package rsa
+
+import (
+	"bytes"
+	"crypto/rand"
+	stdrsa "crypto/rsa"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/Triztian/cryptster/sha"
+)
+
+// The RSAES-OAEP examples of the RSA Laboratories oaep-vect.txt with
+// SHA-1, MGF1 and an empty label
+var oaepVectors = []struct {
+	n, d            string
+	msg, seed, ciph string
+}{
+	// Example 1.1
+	{
+		"a8b3b284af8eb50b387034a860f146c4919f318763cd6c5598c8ae4811a1e0abc4c7e0b082d693a5e7fced675cf4668512772c0cbc64a742c6c630f533c8cc72f62ae833c40bf25842e984bb78bdbf97c0107d55bdb662f5c4e0fab9845cb5148ef7392dd3aaff93ae1e6b667bb3d4247616d4f5ba10d4cfd226de88d39f16fb",
+		"53339cfdb79fc8466a655c7316aca85c55fd8f6dd898fdaf119517ef4f52e8fd8e258df93fee180fa0e4ab29693cd83b152a553d4ac4d1812b8b9fa5af0e7f55fe7304df41570926f3311f15c4d65a732c483116ee3d3d2d0af3549ad9bf7cbfb78ad884f84d5beb04724dc7369b31def37d0cf539e9cfcdd3de653729ead5d1",
+		"6628194e12073db03ba94cda9ef9532397d50dba79b987004afefe34",
+		"18b776ea21069d69776a33e96bad48e1dda0a5ef",
+		"354fe67b4a126d5d35fe36c777791a3f7ba13def484e2d3908aff722fad468fb21696de95d0be911c2d3174f8afcc201035f7b6d8e69402de5451618c21a535fa9d7bfc5b8dd9fc243f8cf927db31322d6e881eaa91a996170e657a05a266426d98c88003f8477c1227094a0d9fa1e8c4024309ce1ecccb5210035d47ac72e8a",
+	},
+	// Example 1.2
+	{
+		"a8b3b284af8eb50b387034a860f146c4919f318763cd6c5598c8ae4811a1e0abc4c7e0b082d693a5e7fced675cf4668512772c0cbc64a742c6c630f533c8cc72f62ae833c40bf25842e984bb78bdbf97c0107d55bdb662f5c4e0fab9845cb5148ef7392dd3aaff93ae1e6b667bb3d4247616d4f5ba10d4cfd226de88d39f16fb",
+		"53339cfdb79fc8466a655c7316aca85c55fd8f6dd898fdaf119517ef4f52e8fd8e258df93fee180fa0e4ab29693cd83b152a553d4ac4d1812b8b9fa5af0e7f55fe7304df41570926f3311f15c4d65a732c483116ee3d3d2d0af3549ad9bf7cbfb78ad884f84d5beb04724dc7369b31def37d0cf539e9cfcdd3de653729ead5d1",
+		"750c4047f547e8e41411856523298ac9bae245efaf1397fbe56f9dd5",
+		"0cc742ce4a9b7f32f951bcb251efd925fe4fe35f",
+		"640db1acc58e0568fe5407e5f9b701dff8c3c91e716c536fc7fcec6cb5b71c1165988d4a279e1577d730fc7a29932e3f00c81515236d8d8e31017a7a09df4352d904cdeb79aa583adcc31ea698a4c05283daba9089be5491f67c1a4ee48dc74bbbe6643aef846679b4cb395a352d5ed115912df696ffe0702932946d71492b44",
+	},
+	// Example 1.3
+	{
+		"a8b3b284af8eb50b387034a860f146c4919f318763cd6c5598c8ae4811a1e0abc4c7e0b082d693a5e7fced675cf4668512772c0cbc64a742c6c630f533c8cc72f62ae833c40bf25842e984bb78bdbf97c0107d55bdb662f5c4e0fab9845cb5148ef7392dd3aaff93ae1e6b667bb3d4247616d4f5ba10d4cfd226de88d39f16fb",
+		"53339cfdb79fc8466a655c7316aca85c55fd8f6dd898fdaf119517ef4f52e8fd8e258df93fee180fa0e4ab29693cd83b152a553d4ac4d1812b8b9fa5af0e7f55fe7304df41570926f3311f15c4d65a732c483116ee3d3d2d0af3549ad9bf7cbfb78ad884f84d5beb04724dc7369b31def37d0cf539e9cfcdd3de653729ead5d1",
+		"d94ae0832e6445ce42331cb06d531a82b1db4baad30f746dc916df24d4e3c2451fff59a6423eb0e1d02d4fe646cf699dfd818c6e97b051",
+		"2514df4695755a67b288eaf4905c36eec66fd2fd",
+		"423736ed035f6026af276c35c0b3741b365e5f76ca091b4e8c29e2f0befee603595aa8322d602d2e625e95eb81b2f1c9724e822eca76db8618cf09c5343503a4360835b5903bc637e3879fb05e0ef32685d5aec5067cd7cc96fe4b2670b6eac3066b1fcf5686b68589aafb7d629b02d8f8625ca3833624d4800fb081b1cf94eb",
+	},
+	// Example 10.1, a 2048 bit key
+	{
+		"ae45ed5601cec6b8cc05f803935c674ddbe0d75c4c09fd7951fc6b0caec313a8df39970c518bffba5ed68f3f0d7f22a4029d413f1ae07e4ebe9e4177ce23e7f5404b569e4ee1bdcf3c1fb03ef113802d4f855eb9b5134b5a7c8085adcae6fa2fa1417ec3763be171b0c62b760ede23c12ad92b980884c641f5a8fac26bdad4a03381a22fe1b754885094c82506d4019a535a286afeb271bb9ba592de18dcf600c2aeeae56e02f7cf79fc14cf3bdc7cd84febbbf950ca90304b2219a7aa063aefa2c3c1980e560cd64afe779585b6107657b957857efde6010988ab7de417fc88d8f384c4e6e72c3f943e0c31c0c4a5cc36f879d8a3ac9d7d59860eaada6b83bb",
+		"056b04216fe5f354ac77250a4b6b0c8525a85c59b0bd80c56450a22d5f438e596a333aa875e291dd43f48cb88b9d5fc0d499f9fcd1c397f9afc070cd9e398c8d19e61db7c7410a6b2675dfbf5d345b804d201add502d5ce2dfcb091ce9997bbebe57306f383e4d588103f036f7e85d1934d152a323e4a8db451d6f4a5b1b0f102cc150e02feee2b88dea4ad4c1baccb24d84072d14e1d24a6771f7408ee30564fb86d4393a34bcf0b788501d193303f13a2284b001f0f649eaf79328d4ac5c430ab4414920a9460ed1b7bc40ec653e876d09abc509ae45b525190116a0c26101848298509c1c3bf3a483e7274054e15e97075036e989f60932807b5257751e79",
+		"8bba6bf82a6c0f86d5f1756e97956870b08953b06b4eb205bc1694ee",
+		"47e1ab7119fee56c95ee5eaad86f40d0aa63bd33",
+		"53ea5dc08cd260fb3b858567287fa91552c30b2febfba213f0ae87702d068d19bab07fe574523dfb42139d68c3c5afeee0bfe4cb7969cbf382b804d6e61396144e2d0e60741f8993c3014b58b9b1957a8babcd23af854f4c356fb1662aa72bfcc7e586559dc4280d160c126785a723ebeebeff71f11594440aaef87d10793a8774a239d4a04c87fe1467b9daf85208ec6c7255794a96cc29142f9a8bd418e3c1fd67344b0cd0829df3b2bec60253196293c6b34d3f75d32f213dd45c6273d505adf4cced1057cb758fc26aeefa441255ed4e64c199ee075e7f16646182fdb464739b68ab5daff0e63e9552016824f054bf4d3c8c90a97bb6b6553284eb429fcc",
+	},
+}
+
+func decodeHex(s string) []byte {
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+// Build a key from the hex modulus and private exponent, e is 65537
+func vectorKey(n, d string) *PrivateKey {
+	priv := new(PrivateKey)
+	priv.N, _ = new(big.Int).SetString(n, 16)
+	priv.E = PublicExponent
+	priv.D, _ = new(big.Int).SetString(d, 16)
+	return priv
+}
+
+func TestOAEPVectors(t *testing.T) {
+	for i, v := range oaepVectors {
+		priv := vectorKey(v.n, v.d)
+		msg, ciph := decodeHex(v.msg), decodeHex(v.ciph)
+
+		c, err := EncryptOAEP(sha.NewSHA1, bytes.NewReader(decodeHex(v.seed)), &priv.PublicKey, msg, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(c, ciph) {
+			t.Errorf("Incorrect OAEP ciphertext of vector %d, expected %s got %x", i, v.ciph, c)
+		}
+
+		m, err := DecryptOAEP(sha.NewSHA1, priv, ciph, nil)
+		if err != nil {
+			t.Errorf("Incorrect OAEP decryption of vector %d, %v", i, err)
+		} else if !bytes.Equal(m, msg) {
+			t.Errorf("Incorrect OAEP plaintext of vector %d, expected %s got %x", i, v.msg, m)
+		}
+	}
+}
+
+func TestOAEPInvalid(t *testing.T) {
+	v := oaepVectors[0]
+	priv := vectorKey(v.n, v.d)
+	ciph := decodeHex(v.ciph)
+
+	// A different label
+	if _, err := DecryptOAEP(sha.NewSHA1, priv, ciph, []byte("label")); err != ErrDecryption {
+		t.Errorf("Expected ErrDecryption for a different label, got %v", err)
+	}
+
+	// A modified ciphertext
+	ciph[len(ciph)-1] ^= 1
+	if _, err := DecryptOAEP(sha.NewSHA1, priv, ciph, nil); err != ErrDecryption {
+		t.Errorf("Expected ErrDecryption for a modified ciphertext, got %v", err)
+	}
+
+	// A ciphertext of the wrong size
+	if _, err := DecryptOAEP(sha.NewSHA1, priv, ciph[1:], nil); err != ErrDecryption {
+		t.Errorf("Expected ErrDecryption for a short ciphertext, got %v", err)
+	}
+
+	// 128 - 2 * 20 - 2 = 86 bytes is the longest message
+	if _, err := EncryptOAEP(sha.NewSHA1, rand.Reader, &priv.PublicKey, make([]byte, 86), nil); err != nil {
+		t.Errorf("Incorrect encryption of the longest message, %v", err)
+	}
+	if _, err := EncryptOAEP(sha.NewSHA1, rand.Reader, &priv.PublicKey, make([]byte, 87), nil); err != ErrMessageTooLong {
+		t.Errorf("Expected ErrMessageTooLong, got %v", err)
+	}
+}
+
+func TestOAEPInterop(t *testing.T) {
+	priv, err := GenerateKey(rand.Reader, 1024)
+	if err != nil {
+		t.Fatal(err)
+	}
+	key := toStdKey(priv)
+	msg := []byte("The quick brown fox jumps over the lazy dog")
+	label := []byte("cryptster")
+
+	// Encrypt with crypto/rsa and decrypt here
+	c, err := stdrsa.EncryptOAEP(sha256.New(), rand.Reader, &key.PublicKey, msg, label)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m, err := DecryptOAEP(sha.NewSHA256, priv, c, label)
+	if err != nil || !bytes.Equal(m, msg) {
+		t.Errorf("Incorrect decryption of a crypto/rsa ciphertext, %v", err)
+	}
+
+	// Encrypt here and decrypt with crypto/rsa
+	c, err = EncryptOAEP(sha.NewSHA256, rand.Reader, &priv.PublicKey, msg, label)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m, err = stdrsa.DecryptOAEP(sha256.New(), nil, key, c, label)
+	if err != nil || !bytes.Equal(m, msg) {
+		t.Errorf("Incorrect decryption by crypto/rsa, %v", err)
+	}
+
+	c, err = EncryptOAEP(sha.NewSHA1, rand.Reader, &priv.PublicKey, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m, err = stdrsa.DecryptOAEP(sha1.New(), nil, key, c, nil)
+	if err != nil || len(m) != 0 {
+		t.Errorf("Incorrect decryption of an empty message by crypto/rsa, %v", err)
+	}
+}
+
+// Convert a key into a crypto/rsa key
+func toStdKey(priv *PrivateKey) *stdrsa.PrivateKey {
+	key := &stdrsa.PrivateKey{
+		PublicKey: stdrsa.PublicKey{N: priv.N, E: priv.E},
+		D:         priv.D,
+		Primes:    []*big.Int{priv.P, priv.Q},
+	}
+	key.Precompute()
+	return key
+}
@@ -0,0 +1,91 @@
+package rsa
+
+import (
+	"crypto/subtle"
+	"io"
+	"math/big"
+)
+
+// Obtain the maximum length of a message that EncryptPKCS1v15 accepts
+// for a key with a modulus of k bytes
+func MaxPKCS1v15MessageLength(k int) int {
+	return k - 11
+}
+
+// EncryptPKCS1v15 encrypts the message with RSAES-PKCS1-v1_5 (RFC 8017),
+// the message can be at most k - 11 bytes long for a modulus of k bytes.
+// It is only provided to interoperate with systems that lack OAEP, new
+// ciphertexts should be produced with EncryptOAEP.
+func EncryptPKCS1v15(random io.Reader, pub *PublicKey, msg []byte) ([]byte, error) {
+	k := pub.Size()
+	if len(msg) > MaxPKCS1v15MessageLength(k) {
+		return nil, ErrMessageTooLong
+	}
+
+	// EM = 0x00 || 0x02 || PS || 0x00 || M
+	em := make([]byte, k)
+	em[1] = 2
+	ps := em[2 : k-len(msg)-1]
+	if err := nonZeroRandomBytes(random, ps); err != nil {
+		return nil, err
+	}
+	copy(em[k-len(msg):], msg)
+
+	c := encrypt(pub, new(big.Int).SetBytes(em))
+	return i2osp(c, k), nil
+}
+
+// DecryptPKCS1v15 decrypts a ciphertext produced by EncryptPKCS1v15.
+// Every decoding failure returns ErrDecryption, the padding is checked
+// in constant time.
+func DecryptPKCS1v15(priv *PrivateKey, ciphertext []byte) ([]byte, error) {
+	k := priv.Size()
+	if len(ciphertext) != k || k < 11 {
+		return nil, ErrDecryption
+	}
+
+	m, err := decrypt(priv, new(big.Int).SetBytes(ciphertext))
+	if err != nil {
+		return nil, err
+	}
+	em := i2osp(m, k)
+
+	good := subtle.ConstantTimeByteEq(em[0], 0)
+	good &= subtle.ConstantTimeByteEq(em[1], 2)
+
+	// Find the first zero byte after PS without branching on the
+	// content of the block
+	var lookingForIndex, index int
+	lookingForIndex = 1
+	for i := 2; i < k; i++ {
+		isZero := subtle.ConstantTimeByteEq(em[i], 0)
+		index = subtle.ConstantTimeSelect(lookingForIndex&isZero, i, index)
+		lookingForIndex = subtle.ConstantTimeSelect(isZero, 0, lookingForIndex)
+	}
+
+	// PS must be at least 8 bytes long
+	validPS := subtle.ConstantTimeLessOrEq(2+8, index)
+
+	if good&^lookingForIndex&validPS != 1 {
+		return nil, ErrDecryption
+	}
+	return em[index+1:], nil
+}
+
+// Fill b with random bytes that are not zero
+func nonZeroRandomBytes(random io.Reader, b []byte) error {
+	if _, err := io.ReadFull(random, b); err != nil {
+		return err
+	}
+
+	var one [1]byte
+	for i := range b {
+		for b[i] == 0 {
+			if _, err := io.ReadFull(random, one[:]); err != nil {
+				return err
+			}
+			b[i] = one[0]
+		}
+	}
+	return nil
+}
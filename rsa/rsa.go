@@ -0,0 +1,74 @@
+// Package rsa implements RSA key generation and encryption.
+package rsa
+
+import (
+	"errors"
+	"math/big"
+	"strconv"
+)
+
+const (
+	MILLER_RABIN_COUNT int = 10
+
+	// The public exponent of the generated keys.
+	PublicExponent = 65537
+)
+
+var (
+	TWO  *big.Int = big.NewInt(2)
+	ZERO *big.Int = big.NewInt(0)
+	ONE  *big.Int = big.NewInt(1)
+)
+
+var (
+	ErrMessageTooLong = errors.New("cryptster/rsa: message too long for the key size")
+	ErrDecryption     = errors.New("cryptster/rsa: decryption error")
+)
+
+type KeySizeError int
+
+func (k KeySizeError) Error() string {
+	return "cryptster/rsa: invalid key size " + strconv.Itoa(int(k))
+}
+
+// A PublicKey is the modulus and public exponent of an RSA key.
+type PublicKey struct {
+	N *big.Int
+	E int
+}
+
+// A PrivateKey holds the private exponent and the primes of the
+// modulus along with the public key.
+type PrivateKey struct {
+	PublicKey
+	D    *big.Int
+	P, Q *big.Int
+}
+
+// The size in bytes of the modulus
+func (pub *PublicKey) Size() int {
+	return (pub.N.BitLen() + 7) / 8
+}
+
+// The RSAEP primitive of RFC 8017, m must be smaller than the modulus
+func encrypt(pub *PublicKey, m *big.Int) *big.Int {
+	return new(big.Int).Exp(m, big.NewInt(int64(pub.E)), pub.N)
+}
+
+// The RSADP primitive of RFC 8017, the ciphertext representative is
+// rejected unless it is smaller than the modulus
+func decrypt(priv *PrivateKey, c *big.Int) (*big.Int, error) {
+	if c.Cmp(priv.N) >= 0 {
+		return nil, ErrDecryption
+	}
+	return new(big.Int).Exp(c, priv.D, priv.N), nil
+}
+
+// Convert x into a big-endian byte string of length k, the I2OSP
+// primitive of RFC 8017; x must fit in k bytes
+func i2osp(x *big.Int, k int) []byte {
+	out := make([]byte, k)
+	b := x.Bytes()
+	copy(out[k-len(b):], b)
+	return out
+}
@@ -0,0 +1,85 @@
+package rsa
+
+import (
+	"crypto/rand"
+	"math/big"
+	"testing"
+)
+
+func TestProbablyPrime(t *testing.T) {
+	var numbers = []struct {
+		n     string
+		prime bool
+	}{
+		{"0", false},
+		{"1", false},
+		{"2", true},
+		{"97", true},
+		// Carmichael numbers
+		{"561", false},
+		{"41041", false},
+		// 2^127 - 1 and 2^127 + 1
+		{"170141183460469231731687303715884105727", true},
+		{"170141183460469231731687303715884105729", false},
+		// A strong pseudoprime to the bases 2 to 23
+		{"3825123056546413051", false},
+	}
+
+	for _, v := range numbers {
+		n, _ := new(big.Int).SetString(v.n, 10)
+		prime, err := ProbablyPrime(n, MILLER_RABIN_COUNT, rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if prime != v.prime {
+			t.Errorf("Incorrect primality of %s, expected %v", v.n, v.prime)
+		}
+	}
+}
+
+func TestRandomPrime(t *testing.T) {
+	p, err := RandomPrime(rand.Reader, 256)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.BitLen() != 256 || p.Bit(254) != 1 {
+		t.Errorf("Incorrect prime size %d", p.BitLen())
+	}
+	if !p.ProbablyPrime(20) {
+		t.Errorf("%s is not prime", p)
+	}
+}
+
+func TestGenerateKey(t *testing.T) {
+	priv, err := GenerateKey(rand.Reader, 1024)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if priv.N.BitLen() != 1024 {
+		t.Errorf("Incorrect modulus size %d", priv.N.BitLen())
+	}
+	if priv.E != PublicExponent {
+		t.Errorf("Incorrect public exponent %d", priv.E)
+	}
+
+	// e * d = 1 mod lcm(p - 1, q - 1)
+	pm1 := new(big.Int).Sub(priv.P, ONE)
+	qm1 := new(big.Int).Sub(priv.Q, ONE)
+	lambda := new(big.Int).Mul(pm1, qm1)
+	lambda.Div(lambda, new(big.Int).GCD(nil, nil, pm1, qm1))
+	ed := new(big.Int).Mul(big.NewInt(int64(priv.E)), priv.D)
+	if ed.Mod(ed, lambda).Cmp(ONE) != 0 {
+		t.Error("The private exponent is not the inverse of e")
+	}
+
+	m := big.NewInt(0x1234567890)
+	c := new(big.Int).Exp(m, big.NewInt(int64(priv.E)), priv.N)
+	if c.Exp(c, priv.D, priv.N).Cmp(m) != 0 {
+		t.Error("Incorrect decryption with the generated key")
+	}
+
+	if _, err := GenerateKey(rand.Reader, 256); err != KeySizeError(256) {
+		t.Errorf("Expected KeySizeError, got %v", err)
+	}
+}
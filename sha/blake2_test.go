@@ -0,0 +1,122 @@
+package sha
+
+import (
+	"encoding/hex"
+	"hash"
+	"testing"
+)
+
+var blake2bVectors = []hashVector{
+	{"", "786a02f742015903c6c6fd852552d272912f4740e15847618a86e217f71f5419d25e1031afee585313896444934eb04b903a685b1448b755d56f701afe9be2ce"},
+	{"abc", "ba80a53f981c4d0d6a2797b69f12f6e94c212f14685ac4b74b12bb6fdbffa2d17d87c5392aab792dc252d5de4533cc9518d38aa8dbf1925ab92386edd4009923"},
+}
+
+var blake2sVectors = []hashVector{
+	{"", "69217a3079908094e11121d042354a7c1f55b6482ca1a51e1b250dfd1ed0eef9"},
+	{"abc", "508c5e8c327c14e2e1a72ba34eeb452f37458b209ed63a294d999b4c86675982"},
+}
+
+func TestBLAKE2b(t *testing.T) {
+	testHash(t, "BLAKE2b", BLAKE2b{}, func() hash.Hash {
+		d, _ := NewBLAKE2b(BLAKE2bSize, nil)
+		return d
+	}, blake2bVectors)
+}
+
+func TestBLAKE2s(t *testing.T) {
+	testHash(t, "BLAKE2s", BLAKE2s{}, func() hash.Hash {
+		d, _ := NewBLAKE2s(BLAKE2sSize, nil)
+		return d
+	}, blake2sVectors)
+}
+
+// The deterministic sequence of RFC 7693 Appendix E
+func selftestSeq(n int, seed uint32) []byte {
+	out := make([]byte, n)
+	a := 0xDEAD4BAD * seed
+	b := uint32(1)
+	for i := range out {
+		t := a + b
+		a = b
+		b = t
+		out[i] = byte(t >> 24)
+	}
+	return out
+}
+
+// Hash the digests of every combination of digest size, input size and
+// keyed or unkeyed mode, the result is compared with the grand hash of
+// RFC 7693 Appendix E
+func blake2Selftest(t *testing.T, name string, newHash func(int, []byte) (hash.Hash, error), sizes, inputs []int, grand string) {
+	all, _ := newHash(32, nil)
+
+	for _, size := range sizes {
+		for _, inLen := range inputs {
+			in := selftestSeq(inLen, uint32(inLen))
+
+			d, err := newHash(size, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			d.Write(in)
+			all.Write(d.Sum(nil))
+
+			d, err = newHash(size, selftestSeq(size, uint32(size)))
+			if err != nil {
+				t.Fatal(err)
+			}
+			d.Write(in)
+			all.Write(d.Sum(nil))
+		}
+	}
+
+	if computedHex := hex.EncodeToString(all.Sum(nil)); computedHex != grand {
+		t.Errorf("Incorrect %s self test hash, expected %s got %s", name, grand, computedHex)
+	}
+}
+
+func TestBLAKE2bSelftest(t *testing.T) {
+	blake2Selftest(t, "BLAKE2b", NewBLAKE2b, []int{20, 32, 48, 64}, []int{0, 3, 128, 129, 255, 1024},
+		"c23a7800d98123bd10f506c61e29da5603d763b8bbad2e737f5e765a7bccd475")
+}
+
+func TestBLAKE2sSelftest(t *testing.T) {
+	blake2Selftest(t, "BLAKE2s", NewBLAKE2s, []int{16, 20, 28, 32}, []int{0, 3, 64, 65, 255, 1024},
+		"6a411f08ce25adcdfb02aba641451cec53c598b24f4fc787fbdc88797f4c1dfe")
+}
+
+// Obtain the bytes 0, 1, ..., n-1
+func byteSequence(n int) []byte {
+	b := make([]byte, n)
+	for i := range b {
+		b[i] = byte(i)
+	}
+	return b
+}
+
+// The last keyed vectors of the BLAKE2 reference implementation, the
+// key is 0, 1, ... and the message 0, 1, ..., 254
+func TestBLAKE2Keyed(t *testing.T) {
+	d, _ := NewBLAKE2b(BLAKE2bSize, byteSequence(64))
+	d.Write([]byte("abc"))
+	d.Reset()
+	d.Write(byteSequence(255))
+	expected := "142709d62e28fcccd0af97fad0f8465b971e82201dc51070faa0372aa43e92484be1c1e73ba10906d5d1853db6a4106e0a7bf9800d373d6dee2d46d62ef2a461"
+	if computedHex := hex.EncodeToString(d.Sum(nil)); computedHex != expected {
+		t.Errorf("Incorrect keyed BLAKE2b digest after Reset, expected %s got %s", expected, computedHex)
+	}
+
+	d, _ = NewBLAKE2s(BLAKE2sSize, byteSequence(32))
+	d.Write(byteSequence(255))
+	expected = "3fb735061abc519dfe979e54c1ee5bfad0a9d858b3315bad34bde999efd724dd"
+	if computedHex := hex.EncodeToString(d.Sum(nil)); computedHex != expected {
+		t.Errorf("Incorrect keyed BLAKE2s digest, expected %s got %s", expected, computedHex)
+	}
+
+	if _, err := NewBLAKE2b(65, nil); err != ErrBLAKE2Size {
+		t.Errorf("Expected ErrBLAKE2Size, got %v", err)
+	}
+	if _, err := NewBLAKE2s(BLAKE2sSize, byteSequence(33)); err != ErrBLAKE2Key {
+		t.Errorf("Expected ErrBLAKE2Key, got %v", err)
+	}
+}
@@ -0,0 +1,175 @@
+package sha
+
+import (
+	"encoding/binary"
+	"errors"
+	"hash"
+
+	"github.com/Triztian/cryptster/byteutil"
+)
+
+const (
+	// The largest and default size of a BLAKE2b digest in bytes.
+	BLAKE2bSize = 64
+
+	// The largest size of a BLAKE2b key in bytes.
+	BLAKE2bKeySize = 64
+
+	// The block size of BLAKE2b in bytes.
+	BLAKE2bBlockSize = 128
+)
+
+var (
+	ErrBLAKE2Size = errors.New("cryptster/sha: invalid BLAKE2 digest size")
+	ErrBLAKE2Key  = errors.New("cryptster/sha: BLAKE2 key too long")
+)
+
+// The message word permutations of the BLAKE2 rounds (RFC 7693, 2.7)
+var blake2Sigma = [10][16]byte{
+	{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15},
+	{14, 10, 4, 8, 9, 15, 13, 6, 1, 12, 0, 2, 11, 7, 5, 3},
+	{11, 8, 12, 0, 5, 2, 15, 13, 10, 14, 3, 6, 7, 1, 9, 4},
+	{7, 9, 3, 1, 13, 12, 11, 14, 2, 6, 5, 10, 4, 0, 15, 8},
+	{9, 0, 5, 7, 2, 4, 10, 15, 14, 1, 11, 12, 6, 8, 3, 13},
+	{2, 12, 6, 10, 0, 11, 8, 3, 4, 13, 7, 5, 15, 14, 1, 9},
+	{12, 5, 1, 15, 14, 13, 4, 10, 0, 7, 6, 3, 9, 2, 8, 11},
+	{13, 11, 7, 14, 12, 1, 3, 9, 5, 0, 15, 4, 8, 6, 2, 10},
+	{6, 15, 14, 9, 11, 3, 0, 8, 12, 2, 13, 7, 1, 4, 10, 5},
+	{10, 2, 8, 4, 7, 6, 1, 5, 15, 11, 9, 14, 3, 12, 13, 0},
+}
+
+// BLAKE2b computes unkeyed 64 byte BLAKE2b digests.
+type BLAKE2b struct{}
+
+// Perform a BLAKE2b message digest
+func (sha BLAKE2b) Digest(message []byte) []byte {
+	d, _ := NewBLAKE2b(BLAKE2bSize, nil)
+	d.Write(message)
+	return d.Sum(nil)
+}
+
+// blake2bDigest is the incremental state of a BLAKE2b computation, the
+// last block is kept in x until Sum since it is compressed differently.
+type blake2bDigest struct {
+	h      [8]uint64
+	t      [2]uint64
+	x      [BLAKE2bBlockSize]byte
+	nx     int
+	size   int
+	key    [BLAKE2bBlockSize]byte
+	keyLen int
+}
+
+// NewBLAKE2b returns a new hash.Hash computing the BLAKE2b checksum of
+// size bytes, between 1 and 64. A key of up to 64 bytes makes it a MAC.
+func NewBLAKE2b(size int, key []byte) (hash.Hash, error) {
+	if size < 1 || size > BLAKE2bSize {
+		return nil, ErrBLAKE2Size
+	}
+	if len(key) > BLAKE2bKeySize {
+		return nil, ErrBLAKE2Key
+	}
+
+	d := &blake2bDigest{size: size, keyLen: len(key)}
+	copy(d.key[:], key)
+	d.Reset()
+	return d, nil
+}
+
+func (d *blake2bDigest) Reset() {
+	d.h = sha512Init
+	d.h[0] ^= 0x01010000 ^ uint64(d.keyLen)<<8 ^ uint64(d.size)
+	d.t = [2]uint64{}
+	d.nx = 0
+
+	// The key is processed as a first block padded with zeros
+	if d.keyLen > 0 {
+		d.x = d.key
+		d.nx = BLAKE2bBlockSize
+	}
+}
+
+func (d *blake2bDigest) Size() int { return d.size }
+
+func (d *blake2bDigest) BlockSize() int { return BLAKE2bBlockSize }
+
+func (d *blake2bDigest) Write(p []byte) (int, error) {
+	n := len(p)
+
+	for len(p) > 0 {
+		if d.nx == BLAKE2bBlockSize {
+			d.compress(BLAKE2bBlockSize, false)
+			d.nx = 0
+		}
+		c := copy(d.x[d.nx:], p)
+		d.nx += c
+		p = p[c:]
+	}
+	return n, nil
+}
+
+// Append the digest of the data written so far to in,
+// the state of the hash is not changed.
+func (d *blake2bDigest) Sum(in []byte) []byte {
+	c := *d
+	for i := c.nx; i < BLAKE2bBlockSize; i++ {
+		c.x[i] = 0
+	}
+	c.compress(c.nx, true)
+
+	var digest [BLAKE2bSize]byte
+	for i, h := range c.h {
+		binary.LittleEndian.PutUint64(digest[i*8:], h)
+	}
+	return append(in, digest[:c.size]...)
+}
+
+// Compress the buffered block, n is the number of message bytes in it
+func (d *blake2bDigest) compress(n int, final bool) {
+	var m [16]uint64
+	for i := range m {
+		m[i] = binary.LittleEndian.Uint64(d.x[i*8:])
+	}
+
+	d.t[0] += uint64(n)
+	if d.t[0] < uint64(n) {
+		d.t[1]++
+	}
+
+	var v [16]uint64
+	copy(v[:8], d.h[:])
+	copy(v[8:], sha512Init[:])
+	v[12] ^= d.t[0]
+	v[13] ^= d.t[1]
+	if final {
+		v[14] = ^v[14]
+	}
+
+	for i := 0; i < 12; i++ {
+		s := &blake2Sigma[i%10]
+		blake2bG(&v, 0, 4, 8, 12, m[s[0]], m[s[1]])
+		blake2bG(&v, 1, 5, 9, 13, m[s[2]], m[s[3]])
+		blake2bG(&v, 2, 6, 10, 14, m[s[4]], m[s[5]])
+		blake2bG(&v, 3, 7, 11, 15, m[s[6]], m[s[7]])
+		blake2bG(&v, 0, 5, 10, 15, m[s[8]], m[s[9]])
+		blake2bG(&v, 1, 6, 11, 12, m[s[10]], m[s[11]])
+		blake2bG(&v, 2, 7, 8, 13, m[s[12]], m[s[13]])
+		blake2bG(&v, 3, 4, 9, 14, m[s[14]], m[s[15]])
+	}
+
+	for i := range d.h {
+		d.h[i] ^= v[i] ^ v[i+8]
+	}
+}
+
+// The BLAKE2b mixing function
+func blake2bG(v *[16]uint64, a, b, c, d int, x, y uint64) {
+	v[a] = v[a] + v[b] + x
+	v[d] = byteutil.Rrot64(v[d]^v[a], 32)
+	v[c] = v[c] + v[d]
+	v[b] = byteutil.Rrot64(v[b]^v[c], 24)
+	v[a] = v[a] + v[b] + y
+	v[d] = byteutil.Rrot64(v[d]^v[a], 16)
+	v[c] = v[c] + v[d]
+	v[b] = byteutil.Rrot64(v[b]^v[c], 63)
+}
@@ -0,0 +1,155 @@
+package sha
+
+import (
+	"encoding/binary"
+	"hash"
+
+	"github.com/Triztian/cryptster/byteutil"
+)
+
+const (
+	// The largest and default size of a BLAKE2s digest in bytes.
+	BLAKE2sSize = 32
+
+	// The largest size of a BLAKE2s key in bytes.
+	BLAKE2sKeySize = 32
+
+	// The block size of BLAKE2s in bytes.
+	BLAKE2sBlockSize = 64
+)
+
+// BLAKE2s computes unkeyed 32 byte BLAKE2s digests.
+type BLAKE2s struct{}
+
+// Perform a BLAKE2s message digest
+func (sha BLAKE2s) Digest(message []byte) []byte {
+	d, _ := NewBLAKE2s(BLAKE2sSize, nil)
+	d.Write(message)
+	return d.Sum(nil)
+}
+
+// blake2sDigest is the incremental state of a BLAKE2s computation, the
+// last block is kept in x until Sum since it is compressed differently.
+type blake2sDigest struct {
+	h      [8]uint32
+	t      [2]uint32
+	x      [BLAKE2sBlockSize]byte
+	nx     int
+	size   int
+	key    [BLAKE2sBlockSize]byte
+	keyLen int
+}
+
+// NewBLAKE2s returns a new hash.Hash computing the BLAKE2s checksum of
+// size bytes, between 1 and 32. A key of up to 32 bytes makes it a MAC.
+func NewBLAKE2s(size int, key []byte) (hash.Hash, error) {
+	if size < 1 || size > BLAKE2sSize {
+		return nil, ErrBLAKE2Size
+	}
+	if len(key) > BLAKE2sKeySize {
+		return nil, ErrBLAKE2Key
+	}
+
+	d := &blake2sDigest{size: size, keyLen: len(key)}
+	copy(d.key[:], key)
+	d.Reset()
+	return d, nil
+}
+
+func (d *blake2sDigest) Reset() {
+	d.h = sha256Init
+	d.h[0] ^= 0x01010000 ^ uint32(d.keyLen)<<8 ^ uint32(d.size)
+	d.t = [2]uint32{}
+	d.nx = 0
+
+	// The key is processed as a first block padded with zeros
+	if d.keyLen > 0 {
+		d.x = d.key
+		d.nx = BLAKE2sBlockSize
+	}
+}
+
+func (d *blake2sDigest) Size() int { return d.size }
+
+func (d *blake2sDigest) BlockSize() int { return BLAKE2sBlockSize }
+
+func (d *blake2sDigest) Write(p []byte) (int, error) {
+	n := len(p)
+
+	for len(p) > 0 {
+		if d.nx == BLAKE2sBlockSize {
+			d.compress(BLAKE2sBlockSize, false)
+			d.nx = 0
+		}
+		c := copy(d.x[d.nx:], p)
+		d.nx += c
+		p = p[c:]
+	}
+	return n, nil
+}
+
+// Append the digest of the data written so far to in,
+// the state of the hash is not changed.
+func (d *blake2sDigest) Sum(in []byte) []byte {
+	c := *d
+	for i := c.nx; i < BLAKE2sBlockSize; i++ {
+		c.x[i] = 0
+	}
+	c.compress(c.nx, true)
+
+	var digest [BLAKE2sSize]byte
+	for i, h := range c.h {
+		binary.LittleEndian.PutUint32(digest[i*4:], h)
+	}
+	return append(in, digest[:c.size]...)
+}
+
+// Compress the buffered block, n is the number of message bytes in it
+func (d *blake2sDigest) compress(n int, final bool) {
+	var m [16]uint32
+	for i := range m {
+		m[i] = binary.LittleEndian.Uint32(d.x[i*4:])
+	}
+
+	d.t[0] += uint32(n)
+	if d.t[0] < uint32(n) {
+		d.t[1]++
+	}
+
+	var v [16]uint32
+	copy(v[:8], d.h[:])
+	copy(v[8:], sha256Init[:])
+	v[12] ^= d.t[0]
+	v[13] ^= d.t[1]
+	if final {
+		v[14] = ^v[14]
+	}
+
+	for i := 0; i < 10; i++ {
+		s := &blake2Sigma[i]
+		blake2sG(&v, 0, 4, 8, 12, m[s[0]], m[s[1]])
+		blake2sG(&v, 1, 5, 9, 13, m[s[2]], m[s[3]])
+		blake2sG(&v, 2, 6, 10, 14, m[s[4]], m[s[5]])
+		blake2sG(&v, 3, 7, 11, 15, m[s[6]], m[s[7]])
+		blake2sG(&v, 0, 5, 10, 15, m[s[8]], m[s[9]])
+		blake2sG(&v, 1, 6, 11, 12, m[s[10]], m[s[11]])
+		blake2sG(&v, 2, 7, 8, 13, m[s[12]], m[s[13]])
+		blake2sG(&v, 3, 4, 9, 14, m[s[14]], m[s[15]])
+	}
+
+	for i := range d.h {
+		d.h[i] ^= v[i] ^ v[i+8]
+	}
+}
+
+// The BLAKE2s mixing function
+func blake2sG(v *[16]uint32, a, b, c, d int, x, y uint32) {
+	v[a] = v[a] + v[b] + x
+	v[d] = byteutil.Rrot32(v[d]^v[a], 16)
+	v[c] = v[c] + v[d]
+	v[b] = byteutil.Rrot32(v[b]^v[c], 12)
+	v[a] = v[a] + v[b] + y
+	v[d] = byteutil.Rrot32(v[d]^v[a], 8)
+	v[c] = v[c] + v[d]
+	v[b] = byteutil.Rrot32(v[b]^v[c], 7)
+}
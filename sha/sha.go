@@ -0,0 +1,32 @@
+// Package sha implements the secure hash algorithms.
+package sha
+
+const (
+	SHA_A uint32 = 0x67452301
+	SHA_B uint32 = 0xEFCDAB89
+	SHA_C uint32 = 0x98BADCFE
+	SHA_D uint32 = 0x10325476
+	SHA_E uint32 = 0xC3D2E1F0
+
+	// The K values used in the main loop
+	K_0_19  uint32 = 0x5A827999
+	K_20_39 uint32 = 0x6ED9EBA1
+	K_40_59 uint32 = 0x8F1BBCDC
+	K_60_79 uint32 = 0xCA62C1D6
+)
+
+// A SHA computes the digest of a whole message.
+type SHA interface {
+	Digest(message []byte) []byte
+}
+
+// SHA1 computes SHA-1 digests, for large inputs use NewSHA1
+// which processes the message incrementally.
+type SHA1 struct{}
+
+// Perform a SHA1 message digest
+func (sha SHA1) Digest(message []byte) []byte {
+	d := NewSHA1()
+	d.Write(message)
+	return d.Sum(nil)
+}
@@ -0,0 +1,149 @@
+package sha
+
+import (
+	"hash"
+
+	"github.com/Triztian/cryptster/byteutil"
+)
+
+const (
+	// The size of a SHA-1 digest in bytes.
+	SHA1Size = 20
+
+	// The block size of SHA-1 in bytes.
+	SHA1BlockSize = 64
+)
+
+// sha1Digest is the incremental state of a SHA-1 computation.
+type sha1Digest struct {
+	h   [5]uint32
+	x   [SHA1BlockSize]byte
+	nx  int
+	len uint64
+}
+
+// NewSHA1 returns a new hash.Hash computing the SHA-1 checksum, the
+// message is processed in 64 byte blocks as it is written.
+func NewSHA1() hash.Hash {
+	d := new(sha1Digest)
+	d.Reset()
+	return d
+}
+
+func (d *sha1Digest) Reset() {
+	d.h = [5]uint32{SHA_A, SHA_B, SHA_C, SHA_D, SHA_E}
+	d.nx = 0
+	d.len = 0
+}
+
+func (d *sha1Digest) Size() int { return SHA1Size }
+
+func (d *sha1Digest) BlockSize() int { return SHA1BlockSize }
+
+func (d *sha1Digest) Write(p []byte) (int, error) {
+	n := len(p)
+	d.len += uint64(n)
+
+	// Complete the buffered block first
+	if d.nx > 0 {
+		c := copy(d.x[d.nx:], p)
+		d.nx += c
+		p = p[c:]
+		if d.nx == SHA1BlockSize {
+			d.block(d.x[:])
+			d.nx = 0
+		}
+	}
+
+	if len(p) >= SHA1BlockSize {
+		full := len(p) &^ (SHA1BlockSize - 1)
+		d.block(p[:full])
+		p = p[full:]
+	}
+
+	d.nx += copy(d.x[:], p)
+	return n, nil
+}
+
+// Append the digest of the data written so far to in,
+// the state of the hash is not changed.
+func (d *sha1Digest) Sum(in []byte) []byte {
+	c := *d
+	ml := c.len * 8
+
+	// Preprocessing; Pad the message with a 1 bit and 0's until it
+	// is congruent with 448 (mod 512)
+	var pad [SHA1BlockSize]byte
+	pad[0] = 0x80
+	if c.len%64 < 56 {
+		c.Write(pad[:56-c.len%64])
+	} else {
+		c.Write(pad[:64+56-c.len%64])
+	}
+
+	// Append the original message length as a 64 bit integer
+	// Why 64? because 512 - 448 = 64, the remaining bits from the
+	// preprocessing
+	c.Write(byteutil.GetBytes64(ml))
+
+	for _, h := range c.h {
+		in = append(in, byteutil.GetBytes32(h)...)
+	}
+	return in
+}
+
+// Process the 64 byte chunks of the message
+func (dg *sha1Digest) block(p []byte) {
+	var w [80]uint32
+
+	h0, h1, h2, h3, h4 := dg.h[0], dg.h[1], dg.h[2], dg.h[3], dg.h[4]
+
+	for len(p) >= SHA1BlockSize {
+		chunk := p[:SHA1BlockSize]
+		a, b, c, d, e := h0, h1, h2, h3, h4
+
+		for i := 0; i < 80; i++ {
+			var f, k uint32
+
+			if i < 16 {
+				w[i] = byteutil.GetInt32(chunk[i*4 : i*4+4])
+			} else {
+				w[i] = byteutil.Lrot32(w[i-3]^w[i-8]^w[i-14]^w[i-16], 1)
+			}
+
+			if byteutil.Between(i, 0, 19) {
+				f = (b & c) | (^b & d)
+				k = K_0_19
+
+			} else if byteutil.Between(i, 20, 39) {
+				f = b ^ c ^ d
+				k = K_20_39
+
+			} else if byteutil.Between(i, 40, 59) {
+				f = (b & c) | (b & d) | (c & d)
+				k = K_40_59
+
+			} else {
+				f = b ^ c ^ d
+				k = K_60_79
+			}
+
+			tmp := byteutil.Lrot32(a, 5) + f + e + k + w[i]
+			e = d
+			d = c
+			c = byteutil.Lrot32(b, 30)
+			b = a
+			a = tmp
+		}
+
+		h0 += a
+		h1 += b
+		h2 += c
+		h3 += d
+		h4 += e
+
+		p = p[SHA1BlockSize:]
+	}
+
+	dg.h[0], dg.h[1], dg.h[2], dg.h[3], dg.h[4] = h0, h1, h2, h3, h4
+}
@@ -0,0 +1,199 @@
+package sha
+
+import (
+	"hash"
+
+	"github.com/Triztian/cryptster/byteutil"
+)
+
+const (
+	// The size of a SHA-256 digest in bytes.
+	SHA256Size = 32
+
+	// The size of a SHA-224 digest in bytes.
+	SHA224Size = 28
+
+	// The block size of SHA-256 and SHA-224 in bytes.
+	SHA256BlockSize = 64
+)
+
+// The initial hash values of SHA-256, the first 32 bits of the fractional
+// parts of the square roots of the first 8 primes
+var sha256Init = [8]uint32{
+	0x6a09e667, 0xbb67ae85, 0x3c6ef372, 0xa54ff53a,
+	0x510e527f, 0x9b05688c, 0x1f83d9ab, 0x5be0cd19,
+}
+
+// The initial hash values of SHA-224
+var sha224Init = [8]uint32{
+	0xc1059ed8, 0x367cd507, 0x3070dd17, 0xf70e5939,
+	0xffc00b31, 0x68581511, 0x64f98fa7, 0xbefa4fa4,
+}
+
+// The round constants, the first 32 bits of the fractional parts of
+// the cube roots of the first 64 primes
+var sha256K = [64]uint32{
+	0x428a2f98, 0x71374491, 0xb5c0fbcf, 0xe9b5dba5, 0x3956c25b, 0x59f111f1, 0x923f82a4, 0xab1c5ed5,
+	0xd807aa98, 0x12835b01, 0x243185be, 0x550c7dc3, 0x72be5d74, 0x80deb1fe, 0x9bdc06a7, 0xc19bf174,
+	0xe49b69c1, 0xefbe4786, 0x0fc19dc6, 0x240ca1cc, 0x2de92c6f, 0x4a7484aa, 0x5cb0a9dc, 0x76f988da,
+	0x983e5152, 0xa831c66d, 0xb00327c8, 0xbf597fc7, 0xc6e00bf3, 0xd5a79147, 0x06ca6351, 0x14292967,
+	0x27b70a85, 0x2e1b2138, 0x4d2c6dfc, 0x53380d13, 0x650a7354, 0x766a0abb, 0x81c2c92e, 0x92722c85,
+	0xa2bfe8a1, 0xa81a664b, 0xc24b8b70, 0xc76c51a3, 0xd192e819, 0xd6990624, 0xf40e3585, 0x106aa070,
+	0x19a4c116, 0x1e376c08, 0x2748774c, 0x34b0bcb5, 0x391c0cb3, 0x4ed8aa4a, 0x5b9cca4f, 0x682e6ff3,
+	0x748f82ee, 0x78a5636f, 0x84c87814, 0x8cc70208, 0x90befffa, 0xa4506ceb, 0xbef9a3f7, 0xc67178f2,
+}
+
+// SHA256 computes SHA-256 digests.
+type SHA256 struct{}
+
+// Perform a SHA-256 message digest
+func (sha SHA256) Digest(message []byte) []byte {
+	d := NewSHA256()
+	d.Write(message)
+	return d.Sum(nil)
+}
+
+// SHA224 computes SHA-224 digests.
+type SHA224 struct{}
+
+// Perform a SHA-224 message digest
+func (sha SHA224) Digest(message []byte) []byte {
+	d := NewSHA224()
+	d.Write(message)
+	return d.Sum(nil)
+}
+
+// sha256Digest is the incremental state of a SHA-256 or SHA-224
+// computation, SHA-224 uses other initial values and truncates
+// the result.
+type sha256Digest struct {
+	h    [8]uint32
+	x    [SHA256BlockSize]byte
+	nx   int
+	len  uint64
+	size int
+}
+
+// NewSHA256 returns a new hash.Hash computing the SHA-256 checksum.
+func NewSHA256() hash.Hash {
+	d := &sha256Digest{size: SHA256Size}
+	d.Reset()
+	return d
+}
+
+// NewSHA224 returns a new hash.Hash computing the SHA-224 checksum.
+func NewSHA224() hash.Hash {
+	d := &sha256Digest{size: SHA224Size}
+	d.Reset()
+	return d
+}
+
+func (d *sha256Digest) Reset() {
+	if d.size == SHA224Size {
+		d.h = sha224Init
+	} else {
+		d.h = sha256Init
+	}
+	d.nx = 0
+	d.len = 0
+}
+
+func (d *sha256Digest) Size() int { return d.size }
+
+func (d *sha256Digest) BlockSize() int { return SHA256BlockSize }
+
+func (d *sha256Digest) Write(p []byte) (int, error) {
+	n := len(p)
+	d.len += uint64(n)
+
+	// Complete the buffered block first
+	if d.nx > 0 {
+		c := copy(d.x[d.nx:], p)
+		d.nx += c
+		p = p[c:]
+		if d.nx == SHA256BlockSize {
+			d.block(d.x[:])
+			d.nx = 0
+		}
+	}
+
+	if len(p) >= SHA256BlockSize {
+		full := len(p) &^ (SHA256BlockSize - 1)
+		d.block(p[:full])
+		p = p[full:]
+	}
+
+	d.nx += copy(d.x[:], p)
+	return n, nil
+}
+
+// Append the digest of the data written so far to in,
+// the state of the hash is not changed.
+func (d *sha256Digest) Sum(in []byte) []byte {
+	c := *d
+	ml := c.len * 8
+
+	// Pad with a 1 bit and 0's until the length is 448 (mod 512)
+	var pad [SHA256BlockSize]byte
+	pad[0] = 0x80
+	if c.len%64 < 56 {
+		c.Write(pad[:56-c.len%64])
+	} else {
+		c.Write(pad[:64+56-c.len%64])
+	}
+	c.Write(byteutil.GetBytes64(ml))
+
+	var digest []byte
+	for _, h := range c.h {
+		digest = append(digest, byteutil.GetBytes32(h)...)
+	}
+	return append(in, digest[:c.size]...)
+}
+
+// Process the 64 byte chunks of the message
+func (dg *sha256Digest) block(p []byte) {
+	var w [64]uint32
+
+	for len(p) >= SHA256BlockSize {
+		for i := 0; i < 16; i++ {
+			w[i] = byteutil.GetInt32(p[i*4 : i*4+4])
+		}
+		for i := 16; i < 64; i++ {
+			s0 := byteutil.Rrot32(w[i-15], 7) ^ byteutil.Rrot32(w[i-15], 18) ^ (w[i-15] >> 3)
+			s1 := byteutil.Rrot32(w[i-2], 17) ^ byteutil.Rrot32(w[i-2], 19) ^ (w[i-2] >> 10)
+			w[i] = w[i-16] + s0 + w[i-7] + s1
+		}
+
+		a, b, c, d, e, f, g, h := dg.h[0], dg.h[1], dg.h[2], dg.h[3], dg.h[4], dg.h[5], dg.h[6], dg.h[7]
+
+		for i := 0; i < 64; i++ {
+			s1 := byteutil.Rrot32(e, 6) ^ byteutil.Rrot32(e, 11) ^ byteutil.Rrot32(e, 25)
+			ch := (e & f) ^ (^e & g)
+			t1 := h + s1 + ch + sha256K[i] + w[i]
+
+			s0 := byteutil.Rrot32(a, 2) ^ byteutil.Rrot32(a, 13) ^ byteutil.Rrot32(a, 22)
+			maj := (a & b) ^ (a & c) ^ (b & c)
+			t2 := s0 + maj
+
+			h = g
+			g = f
+			f = e
+			e = d + t1
+			d = c
+			c = b
+			b = a
+			a = t1 + t2
+		}
+
+		dg.h[0] += a
+		dg.h[1] += b
+		dg.h[2] += c
+		dg.h[3] += d
+		dg.h[4] += e
+		dg.h[5] += f
+		dg.h[6] += g
+		dg.h[7] += h
+
+		p = p[SHA256BlockSize:]
+	}
+}
@@ -0,0 +1,60 @@
+package sha
+
+import (
+	"encoding/hex"
+	"hash"
+	"strings"
+	"testing"
+)
+
+type hashVector struct {
+	message, digest string
+}
+
+// Check the vectors both through the SHA interface and by
+// writing the message to the hash in uneven pieces
+func testHash(t *testing.T, name string, sha SHA, newHash func() hash.Hash, vectors []hashVector) {
+	for _, v := range vectors {
+		if computedHex := hex.EncodeToString(sha.Digest([]byte(v.message))); computedHex != v.digest {
+			t.Errorf("Incorrect %s digest of a %d byte message, expected %s got %s", name, len(v.message), v.digest, computedHex)
+		}
+
+		d := newHash()
+		for i := 0; i < len(v.message); i += 13 {
+			end := i + 13
+			if end > len(v.message) {
+				end = len(v.message)
+			}
+			d.Write([]byte(v.message[i:end]))
+		}
+		if computedHex := hex.EncodeToString(d.Sum(nil)); computedHex != v.digest {
+			t.Errorf("Incorrect streaming %s digest of a %d byte message, expected %s got %s", name, len(v.message), v.digest, computedHex)
+		}
+		if d.Size()*2 != len(v.digest) {
+			t.Errorf("Incorrect %s size %d", name, d.Size())
+		}
+	}
+}
+
+// FIPS 180-4 examples and the NIST long message vector
+var sha256Vectors = []hashVector{
+	{"", "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"},
+	{"abc", "ba7816bf8f01cfea414140de5dae2223b00361a396177a9cb410ff61f20015ad"},
+	{"abcdbcdecdefdefgefghfghighijhijkijkljklmklmnlmnomnopnopq", "248d6a61d20638b8e5c026930c3e6039a33ce45964ff2167f6ecedd419db06c1"},
+	{strings.Repeat("a", 1000000), "cdc76e5c9914fb9281a1c7e284d73e67f1809a48a497200e046d39ccc7112cd0"},
+}
+
+var sha224Vectors = []hashVector{
+	{"", "d14a028c2a3a2bc9476102bb288234c415a2b01f828ea62ac5b3e42f"},
+	{"abc", "23097d223405d8228642a477bda255b32aadbce4bda0b3f7e36c9da7"},
+	{"abcdbcdecdefdefgefghfghighijhijkijkljklmklmnlmnomnopnopq", "75388b16512776cc5dba5da1fd890150b0c6455cb4f58b1952522525"},
+	{strings.Repeat("a", 1000000), "20794655980c91d8bbb4c1ea97618a4bf03f42581948b2ee4ee7ad67"},
+}
+
+func TestSHA256(t *testing.T) {
+	testHash(t, "SHA-256", SHA256{}, NewSHA256, sha256Vectors)
+}
+
+func TestSHA224(t *testing.T) {
+	testHash(t, "SHA-224", SHA224{}, NewSHA224, sha224Vectors)
+}
@@ -0,0 +1,172 @@
+package sha
+
+import (
+	"hash"
+	"io"
+)
+
+const (
+	// The sizes of the SHA-3 digests in bytes.
+	SHA3_224Size = 28
+	SHA3_256Size = 32
+	SHA3_384Size = 48
+	SHA3_512Size = 64
+
+	// The sizes of the SHAKE outputs returned by Sum in bytes,
+	// any length can be read from a ShakeHash.
+	SHAKE128Size = 32
+	SHAKE256Size = 64
+)
+
+// The domain separation bits and the first padding bit
+const (
+	sha3Suffix  byte = 0x06
+	shakeSuffix byte = 0x1f
+)
+
+// SHA3_224 computes SHA3-224 digests.
+type SHA3_224 struct{}
+
+// Perform a SHA3-224 message digest
+func (sha SHA3_224) Digest(message []byte) []byte {
+	d := NewSHA3_224()
+	d.Write(message)
+	return d.Sum(nil)
+}
+
+// SHA3_256 computes SHA3-256 digests.
+type SHA3_256 struct{}
+
+// Perform a SHA3-256 message digest
+func (sha SHA3_256) Digest(message []byte) []byte {
+	d := NewSHA3_256()
+	d.Write(message)
+	return d.Sum(nil)
+}
+
+// SHA3_384 computes SHA3-384 digests.
+type SHA3_384 struct{}
+
+// Perform a SHA3-384 message digest
+func (sha SHA3_384) Digest(message []byte) []byte {
+	d := NewSHA3_384()
+	d.Write(message)
+	return d.Sum(nil)
+}
+
+// SHA3_512 computes SHA3-512 digests.
+type SHA3_512 struct{}
+
+// Perform a SHA3-512 message digest
+func (sha SHA3_512) Digest(message []byte) []byte {
+	d := NewSHA3_512()
+	d.Write(message)
+	return d.Sum(nil)
+}
+
+// A ShakeHash is an extendable-output function, after the message is
+// written an output of any length can be read from it. Writing after
+// the first Read panics.
+type ShakeHash interface {
+	hash.Hash
+	io.Reader
+}
+
+// sha3Digest is the sponge state of a SHA-3 or SHAKE computation,
+// the variants differ in their rate, suffix and output size.
+type sha3Digest struct {
+	a         [25]uint64
+	n         int
+	rate      int
+	suffix    byte
+	size      int
+	squeezing bool
+}
+
+// NewSHA3_224 returns a new hash.Hash computing the SHA3-224 checksum.
+func NewSHA3_224() hash.Hash {
+	return &sha3Digest{rate: 144, suffix: sha3Suffix, size: SHA3_224Size}
+}
+
+// NewSHA3_256 returns a new hash.Hash computing the SHA3-256 checksum.
+func NewSHA3_256() hash.Hash {
+	return &sha3Digest{rate: 136, suffix: sha3Suffix, size: SHA3_256Size}
+}
+
+// NewSHA3_384 returns a new hash.Hash computing the SHA3-384 checksum.
+func NewSHA3_384() hash.Hash {
+	return &sha3Digest{rate: 104, suffix: sha3Suffix, size: SHA3_384Size}
+}
+
+// NewSHA3_512 returns a new hash.Hash computing the SHA3-512 checksum.
+func NewSHA3_512() hash.Hash {
+	return &sha3Digest{rate: 72, suffix: sha3Suffix, size: SHA3_512Size}
+}
+
+// NewSHAKE128 returns a new ShakeHash computing SHAKE128 outputs.
+func NewSHAKE128() ShakeHash {
+	return &sha3Digest{rate: 168, suffix: shakeSuffix, size: SHAKE128Size}
+}
+
+// NewSHAKE256 returns a new ShakeHash computing SHAKE256 outputs.
+func NewSHAKE256() ShakeHash {
+	return &sha3Digest{rate: 136, suffix: shakeSuffix, size: SHAKE256Size}
+}
+
+func (d *sha3Digest) Reset() {
+	d.a = [25]uint64{}
+	d.n = 0
+	d.squeezing = false
+}
+
+func (d *sha3Digest) Size() int { return d.size }
+
+func (d *sha3Digest) BlockSize() int { return d.rate }
+
+// Absorb the message into the state, one rate sized block at a time
+func (d *sha3Digest) Write(p []byte) (int, error) {
+	if d.squeezing {
+		panic("cryptster/sha: Write after Read")
+	}
+
+	for _, b := range p {
+		d.a[d.n/8] ^= uint64(b) << (8 * uint(d.n%8))
+		d.n++
+		if d.n == d.rate {
+			keccakF1600(&d.a)
+			d.n = 0
+		}
+	}
+	return len(p), nil
+}
+
+// Squeeze len(p) bytes out of the state, the message is padded on the
+// first Read.
+func (d *sha3Digest) Read(p []byte) (int, error) {
+	if !d.squeezing {
+		d.a[d.n/8] ^= uint64(d.suffix) << (8 * uint(d.n%8))
+		d.a[(d.rate-1)/8] ^= 0x80 << (8 * uint((d.rate-1)%8))
+		keccakF1600(&d.a)
+		d.n = 0
+		d.squeezing = true
+	}
+
+	for i := range p {
+		if d.n == d.rate {
+			keccakF1600(&d.a)
+			d.n = 0
+		}
+		p[i] = byte(d.a[d.n/8] >> (8 * uint(d.n%8)))
+		d.n++
+	}
+	return len(p), nil
+}
+
+// Append the digest of the data written so far to in,
+// the state of the hash is not changed.
+func (d *sha3Digest) Sum(in []byte) []byte {
+	c := *d
+	digest := make([]byte, c.size)
+	c.Read(digest)
+	return append(in, digest...)
+}
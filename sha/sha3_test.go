@@ -0,0 +1,132 @@
+package sha
+
+import (
+	"bytes"
+	"encoding/hex"
+	"hash"
+	"strings"
+	"testing"
+)
+
+// FIPS 202 examples and the NIST long message vector
+var sha3_224Vectors = []hashVector{
+	{"", "6b4e03423667dbb73b6e15454f0eb1abd4597f9a1b078e3f5b5a6bc7"},
+	{"abc", "e642824c3f8cf24ad09234ee7d3c766fc9a3a5168d0c94ad73b46fdf"},
+	{"abcdbcdecdefdefgefghfghighijhijkijkljklmklmnlmnomnopnopq", "8a24108b154ada21c9fd5574494479ba5c7e7ab76ef264ead0fcce33"},
+	{strings.Repeat("\xa3", 200), "9376816aba503f72f96ce7eb65ac095deee3be4bf9bbc2a1cb7e11e0"},
+	{strings.Repeat("a", 1000000), "d69335b93325192e516a912e6d19a15cb51c6ed5c15243e7a7fd653c"},
+}
+
+var sha3_256Vectors = []hashVector{
+	{"", "a7ffc6f8bf1ed76651c14756a061d662f580ff4de43b49fa82d80a4b80f8434a"},
+	{"abc", "3a985da74fe225b2045c172d6bd390bd855f086e3e9d525b46bfe24511431532"},
+	{"abcdbcdecdefdefgefghfghighijhijkijkljklmklmnlmnomnopnopq", "41c0dba2a9d6240849100376a8235e2c82e1b9998a999e21db32dd97496d3376"},
+	{strings.Repeat("\xa3", 200), "79f38adec5c20307a98ef76e8324afbfd46cfd81b22e3973c65fa1bd9de31787"},
+	{strings.Repeat("a", 1000000), "5c8875ae474a3634ba4fd55ec85bffd661f32aca75c6d699d0cdcb6c115891c1"},
+}
+
+var sha3_384Vectors = []hashVector{
+	{"", "0c63a75b845e4f7d01107d852e4c2485c51a50aaaa94fc61995e71bbee983a2ac3713831264adb47fb6bd1e058d5f004"},
+	{"abc", "ec01498288516fc926459f58e2c6ad8df9b473cb0fc08c2596da7cf0e49be4b298d88cea927ac7f539f1edf228376d25"},
+	{"abcdbcdecdefdefgefghfghighijhijkijkljklmklmnlmnomnopnopq", "991c665755eb3a4b6bbdfb75c78a492e8c56a22c5c4d7e429bfdbc32b9d4ad5aa04a1f076e62fea19eef51acd0657c22"},
+	{strings.Repeat("\xa3", 200), "1881de2ca7e41ef95dc4732b8f5f002b189cc1e42b74168ed1732649ce1dbcdd76197a31fd55ee989f2d7050dd473e8f"},
+	{strings.Repeat("a", 1000000), "eee9e24d78c1855337983451df97c8ad9eedf256c6334f8e948d252d5e0e76847aa0774ddb90a842190d2c558b4b8340"},
+}
+
+var sha3_512Vectors = []hashVector{
+	{"", "a69f73cca23a9ac5c8b567dc185a756e97c982164fe25859e0d1dcc1475c80a615b2123af1f5f94c11e3e9402c3ac558f500199d95b6d3e301758586281dcd26"},
+	{"abc", "b751850b1a57168a5693cd924b6b096e08f621827444f70d884f5d0240d2712e10e116e9192af3c91a7ec57647e3934057340b4cf408d5a56592f8274eec53f0"},
+	{"abcdbcdecdefdefgefghfghighijhijkijkljklmklmnlmnomnopnopq", "04a371e84ecfb5b8b77cb48610fca8182dd457ce6f326a0fd3d7ec2f1e91636dee691fbe0c985302ba1b0d8dc78c086346b533b49c030d99a27daf1139d6e75e"},
+	{strings.Repeat("\xa3", 200), "e76dfad22084a8b1467fcf2ffa58361bec7628edf5f3fdc0e4805dc48caeeca81b7c13c30adf52a3659584739a2df46be589c51ca1a4a8416df6545a1ce8ba00"},
+	{strings.Repeat("a", 1000000), "3c3a876da14034ab60627c077bb98f7e120a2a5370212dffb3385a18d4f38859ed311d0a9d5141ce9cc5c66ee689b266a8aa18ace8282a0e0db596c90b0a7b87"},
+}
+
+var shake128Vectors = []hashVector{
+	{"", "7f9c2ba4e88f827d616045507605853ed73b8093f6efbc88eb1a6eacfa66ef26"},
+	{"abc", "5881092dd818bf5cf8a3ddb793fbcba74097d5c526a6d35f97b83351940f2cc8"},
+	{"abcdbcdecdefdefgefghfghighijhijkijkljklmklmnlmnomnopnopq", "1a96182b50fb8c7e74e0a707788f55e98209b8d91fade8f32f8dd5cff7bf21f5"},
+	{strings.Repeat("\xa3", 200), "131ab8d2b594946b9c81333f9bb6e0ce75c3b93104fa3469d3917457385da037"},
+	{strings.Repeat("a", 1000000), "9d222c79c4ff9d092cf6ca86143aa411e369973808ef97093255826c5572ef58"},
+}
+
+var shake256Vectors = []hashVector{
+	{"", "46b9dd2b0ba88d13233b3feb743eeb243fcd52ea62b81b82b50c27646ed5762fd75dc4ddd8c0f200cb05019d67b592f6fc821c49479ab48640292eacb3b7c4be"},
+	{"abc", "483366601360a8771c6863080cc4114d8db44530f8f1e1ee4f94ea37e78b5739d5a15bef186a5386c75744c0527e1faa9f8726e462a12a4feb06bd8801e751e4"},
+	{"abcdbcdecdefdefgefghfghighijhijkijkljklmklmnlmnomnopnopq", "4d8c2dd2435a0128eefbb8c36f6f87133a7911e18d979ee1ae6be5d4fd2e332940d8688a4e6a59aa8060f1f9bc996c05aca3c696a8b66279dc672c740bb224ec"},
+	{strings.Repeat("\xa3", 200), "cd8a920ed141aa0407a22d59288652e9d9f1a7ee0c1e7c1ca699424da84a904d2d700caae7396ece96604440577da4f3aa22aeb8857f961c4cd8e06f0ae6610b"},
+	{strings.Repeat("a", 1000000), "3578a7a4ca9137569cdf76ed617d31bb994fca9c1bbf8b184013de8234dfd13a3fd124d4df76c0a539ee7dd2f6e1ec346124c815d9410e145eb561bcd97b18ab"},
+}
+
+func TestSHA3_224(t *testing.T) {
+	testHash(t, "SHA3-224", SHA3_224{}, NewSHA3_224, sha3_224Vectors)
+}
+
+func TestSHA3_256(t *testing.T) {
+	testHash(t, "SHA3-256", SHA3_256{}, NewSHA3_256, sha3_256Vectors)
+}
+
+func TestSHA3_384(t *testing.T) {
+	testHash(t, "SHA3-384", SHA3_384{}, NewSHA3_384, sha3_384Vectors)
+}
+
+func TestSHA3_512(t *testing.T) {
+	testHash(t, "SHA3-512", SHA3_512{}, NewSHA3_512, sha3_512Vectors)
+}
+
+func TestSHAKE128(t *testing.T) {
+	testHash(t, "SHAKE128", shakeDigest{NewSHAKE128}, func() hash.Hash { return NewSHAKE128() }, shake128Vectors)
+}
+
+func TestSHAKE256(t *testing.T) {
+	testHash(t, "SHAKE256", shakeDigest{NewSHAKE256}, func() hash.Hash { return NewSHAKE256() }, shake256Vectors)
+}
+
+// shakeDigest adapts a SHAKE function to the SHA interface
+type shakeDigest struct {
+	newHash func() ShakeHash
+}
+
+func (s shakeDigest) Digest(message []byte) []byte {
+	d := s.newHash()
+	d.Write(message)
+	return d.Sum(nil)
+}
+
+// Outputs longer than the rate of the "abc" message
+var shakeLongVectors = []struct {
+	name    string
+	newHash func() ShakeHash
+	output  string
+}{
+	{"SHAKE128", NewSHAKE128, "5881092dd818bf5cf8a3ddb793fbcba74097d5c526a6d35f97b83351940f2cc844c50af32acd3f2cdd066568706f509bc1bdde58295dae3f891a9a0fca5783789a41f8611214ce612394df286a62d1a2252aa94db9c538956c717dc2bed4f232a0294c857c730aa16067ac1062f1201fb0d377cfb9cde4c63599b27f3462bba4a0ed296c801f9ff7f57302bb3076ee145f97a32ae68e76ab66c48d51675bd49acc29082f5647584e6aa01b3f5af057805f973ff8ecb8b226ac32ada6f01c1fcd4818cb006aa5b4cd"},
+	{"SHAKE256", NewSHAKE256, "483366601360a8771c6863080cc4114d8db44530f8f1e1ee4f94ea37e78b5739d5a15bef186a5386c75744c0527e1faa9f8726e462a12a4feb06bd8801e751e41385141204f329979fd3047a13c5657724ada64d2470157b3cdc288620944d78dbcddbd912993f0913f164fb2ce95131a2d09a3e6d51cbfc622720d7a75c6334e8a2d7ec71a7cc29cf0ea610eeff1a588290a53000faa79932becec0bd3cd0b33a7e5d397fed1ada9442b99903f4dcfd8559ed3950faf40fe6f3b5d710ed3b677513771af6bfe119"},
+}
+
+// Read the output in uneven pieces, it must match both the vector and
+// a single Read of the same length
+func TestSHAKERead(t *testing.T) {
+	for _, v := range shakeLongVectors {
+		expected, _ := hex.DecodeString(v.output)
+
+		d := v.newHash()
+		d.Write([]byte("abc"))
+		computed := make([]byte, len(expected))
+		for i := 0; i < len(computed); i += 7 {
+			end := i + 7
+			if end > len(computed) {
+				end = len(computed)
+			}
+			d.Read(computed[i:end])
+		}
+		if !bytes.Equal(computed, expected) {
+			t.Errorf("Incorrect %s output, expected %s got %x", v.name, v.output, computed)
+		}
+
+		d.Reset()
+		d.Write([]byte("abc"))
+		d.Read(computed)
+		if !bytes.Equal(computed, expected) {
+			t.Errorf("Incorrect %s output after Reset, expected %s got %x", v.name, v.output, computed)
+		}
+	}
+}
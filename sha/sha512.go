@@ -0,0 +1,262 @@
+package sha
+
+import (
+	"hash"
+
+	"github.com/Triztian/cryptster/byteutil"
+)
+
+const (
+	// The size of a SHA-512 digest in bytes.
+	SHA512Size = 64
+
+	// The size of a SHA-384 digest in bytes.
+	SHA384Size = 48
+
+	// The size of a SHA-512/224 digest in bytes.
+	SHA512_224Size = 28
+
+	// The size of a SHA-512/256 digest in bytes.
+	SHA512_256Size = 32
+
+	// The block size of SHA-512 and its truncated variants in bytes.
+	SHA512BlockSize = 128
+)
+
+// The initial hash values of SHA-512, the first 64 bits of the fractional
+// parts of the square roots of the first 8 primes
+var sha512Init = [8]uint64{
+	0x6a09e667f3bcc908, 0xbb67ae8584caa73b, 0x3c6ef372fe94f82b, 0xa54ff53a5f1d36f1,
+	0x510e527fade682d1, 0x9b05688c2b3e6c1f, 0x1f83d9abfb41bd6b, 0x5be0cd19137e2179,
+}
+
+// The initial hash values of SHA-384, taken from the 9th through
+// 16th primes
+var sha384Init = [8]uint64{
+	0xcbbb9d5dc1059ed8, 0x629a292a367cd507, 0x9159015a3070dd17, 0x152fecd8f70e5939,
+	0x67332667ffc00b31, 0x8eb44a8768581511, 0xdb0c2e0d64f98fa7, 0x47b5481dbefa4fa4,
+}
+
+// The initial hash values of SHA-512/224 (FIPS 180-4, 5.3.6.1)
+var sha512_224Init = [8]uint64{
+	0x8c3d37c819544da2, 0x73e1996689dcd4d6, 0x1dfab7ae32ff9c82, 0x679dd514582f9fcf,
+	0x0f6d2b697bd44da8, 0x77e36f7304c48942, 0x3f9d85a86a1d36c8, 0x1112e6ad91d692a1,
+}
+
+// The initial hash values of SHA-512/256 (FIPS 180-4, 5.3.6.2)
+var sha512_256Init = [8]uint64{
+	0x22312194fc2bf72c, 0x9f555fa3c84c64c2, 0x2393b86b6f53b151, 0x963877195940eabd,
+	0x96283ee2a88effe3, 0xbe5e1e2553863992, 0x2b0199fc2c85b8aa, 0x0eb72ddc81c52ca2,
+}
+
+// The round constants, the first 64 bits of the fractional parts of
+// the cube roots of the first 80 primes
+var sha512K = [80]uint64{
+	0x428a2f98d728ae22, 0x7137449123ef65cd, 0xb5c0fbcfec4d3b2f, 0xe9b5dba58189dbbc,
+	0x3956c25bf348b538, 0x59f111f1b605d019, 0x923f82a4af194f9b, 0xab1c5ed5da6d8118,
+	0xd807aa98a3030242, 0x12835b0145706fbe, 0x243185be4ee4b28c, 0x550c7dc3d5ffb4e2,
+	0x72be5d74f27b896f, 0x80deb1fe3b1696b1, 0x9bdc06a725c71235, 0xc19bf174cf692694,
+	0xe49b69c19ef14ad2, 0xefbe4786384f25e3, 0x0fc19dc68b8cd5b5, 0x240ca1cc77ac9c65,
+	0x2de92c6f592b0275, 0x4a7484aa6ea6e483, 0x5cb0a9dcbd41fbd4, 0x76f988da831153b5,
+	0x983e5152ee66dfab, 0xa831c66d2db43210, 0xb00327c898fb213f, 0xbf597fc7beef0ee4,
+	0xc6e00bf33da88fc2, 0xd5a79147930aa725, 0x06ca6351e003826f, 0x142929670a0e6e70,
+	0x27b70a8546d22ffc, 0x2e1b21385c26c926, 0x4d2c6dfc5ac42aed, 0x53380d139d95b3df,
+	0x650a73548baf63de, 0x766a0abb3c77b2a8, 0x81c2c92e47edaee6, 0x92722c851482353b,
+	0xa2bfe8a14cf10364, 0xa81a664bbc423001, 0xc24b8b70d0f89791, 0xc76c51a30654be30,
+	0xd192e819d6ef5218, 0xd69906245565a910, 0xf40e35855771202a, 0x106aa07032bbd1b8,
+	0x19a4c116b8d2d0c8, 0x1e376c085141ab53, 0x2748774cdf8eeb99, 0x34b0bcb5e19b48a8,
+	0x391c0cb3c5c95a63, 0x4ed8aa4ae3418acb, 0x5b9cca4f7763e373, 0x682e6ff3d6b2b8a3,
+	0x748f82ee5defb2fc, 0x78a5636f43172f60, 0x84c87814a1f0ab72, 0x8cc702081a6439ec,
+	0x90befffa23631e28, 0xa4506cebde82bde9, 0xbef9a3f7b2c67915, 0xc67178f2e372532b,
+	0xca273eceea26619c, 0xd186b8c721c0c207, 0xeada7dd6cde0eb1e, 0xf57d4f7fee6ed178,
+	0x06f067aa72176fba, 0x0a637dc5a2c898a6, 0x113f9804bef90dae, 0x1b710b35131c471b,
+	0x28db77f523047d84, 0x32caab7b40c72493, 0x3c9ebe0a15c9bebc, 0x431d67c49c100d4c,
+	0x4cc5d4becb3e42b6, 0x597f299cfc657e2a, 0x5fcb6fab3ad6faec, 0x6c44198c4a475817,
+}
+
+// SHA512 computes SHA-512 digests.
+type SHA512 struct{}
+
+// Perform a SHA-512 message digest
+func (sha SHA512) Digest(message []byte) []byte {
+	d := NewSHA512()
+	d.Write(message)
+	return d.Sum(nil)
+}
+
+// SHA384 computes SHA-384 digests.
+type SHA384 struct{}
+
+// Perform a SHA-384 message digest
+func (sha SHA384) Digest(message []byte) []byte {
+	d := NewSHA384()
+	d.Write(message)
+	return d.Sum(nil)
+}
+
+// SHA512_224 computes SHA-512/224 digests.
+type SHA512_224 struct{}
+
+// Perform a SHA-512/224 message digest
+func (sha SHA512_224) Digest(message []byte) []byte {
+	d := NewSHA512_224()
+	d.Write(message)
+	return d.Sum(nil)
+}
+
+// SHA512_256 computes SHA-512/256 digests.
+type SHA512_256 struct{}
+
+// Perform a SHA-512/256 message digest
+func (sha SHA512_256) Digest(message []byte) []byte {
+	d := NewSHA512_256()
+	d.Write(message)
+	return d.Sum(nil)
+}
+
+// sha512Digest is the incremental state of a SHA-512 computation or
+// one of its truncated variants, which only differ in their initial
+// values and the size of the result.
+type sha512Digest struct {
+	h    [8]uint64
+	x    [SHA512BlockSize]byte
+	nx   int
+	len  uint64
+	init *[8]uint64
+	size int
+}
+
+// NewSHA512 returns a new hash.Hash computing the SHA-512 checksum.
+func NewSHA512() hash.Hash {
+	return newSHA512(&sha512Init, SHA512Size)
+}
+
+// NewSHA384 returns a new hash.Hash computing the SHA-384 checksum.
+func NewSHA384() hash.Hash {
+	return newSHA512(&sha384Init, SHA384Size)
+}
+
+// NewSHA512_224 returns a new hash.Hash computing the SHA-512/224 checksum.
+func NewSHA512_224() hash.Hash {
+	return newSHA512(&sha512_224Init, SHA512_224Size)
+}
+
+// NewSHA512_256 returns a new hash.Hash computing the SHA-512/256 checksum.
+func NewSHA512_256() hash.Hash {
+	return newSHA512(&sha512_256Init, SHA512_256Size)
+}
+
+func newSHA512(init *[8]uint64, size int) hash.Hash {
+	d := &sha512Digest{init: init, size: size}
+	d.Reset()
+	return d
+}
+
+func (d *sha512Digest) Reset() {
+	d.h = *d.init
+	d.nx = 0
+	d.len = 0
+}
+
+func (d *sha512Digest) Size() int { return d.size }
+
+func (d *sha512Digest) BlockSize() int { return SHA512BlockSize }
+
+func (d *sha512Digest) Write(p []byte) (int, error) {
+	n := len(p)
+	d.len += uint64(n)
+
+	// Complete the buffered block first
+	if d.nx > 0 {
+		c := copy(d.x[d.nx:], p)
+		d.nx += c
+		p = p[c:]
+		if d.nx == SHA512BlockSize {
+			d.block(d.x[:])
+			d.nx = 0
+		}
+	}
+
+	if len(p) >= SHA512BlockSize {
+		full := len(p) &^ (SHA512BlockSize - 1)
+		d.block(p[:full])
+		p = p[full:]
+	}
+
+	d.nx += copy(d.x[:], p)
+	return n, nil
+}
+
+// Append the digest of the data written so far to in,
+// the state of the hash is not changed.
+func (d *sha512Digest) Sum(in []byte) []byte {
+	c := *d
+	n := c.len
+
+	// Pad with a 1 bit and 0's until the length is 896 (mod 1024)
+	var pad [SHA512BlockSize]byte
+	pad[0] = 0x80
+	if n%128 < 112 {
+		c.Write(pad[:112-n%128])
+	} else {
+		c.Write(pad[:128+112-n%128])
+	}
+
+	// The message length in bits is a 128 bit number
+	c.Write(byteutil.GetBytes64(n >> 61))
+	c.Write(byteutil.GetBytes64(n << 3))
+
+	var digest []byte
+	for _, h := range c.h {
+		digest = append(digest, byteutil.GetBytes64(h)...)
+	}
+	return append(in, digest[:c.size]...)
+}
+
+// Process the 128 byte chunks of the message
+func (dg *sha512Digest) block(p []byte) {
+	var w [80]uint64
+
+	for len(p) >= SHA512BlockSize {
+		for i := 0; i < 16; i++ {
+			w[i] = byteutil.GetInt64(p[i*8 : i*8+8])
+		}
+		for i := 16; i < 80; i++ {
+			s0 := byteutil.Rrot64(w[i-15], 1) ^ byteutil.Rrot64(w[i-15], 8) ^ (w[i-15] >> 7)
+			s1 := byteutil.Rrot64(w[i-2], 19) ^ byteutil.Rrot64(w[i-2], 61) ^ (w[i-2] >> 6)
+			w[i] = w[i-16] + s0 + w[i-7] + s1
+		}
+
+		a, b, c, d, e, f, g, h := dg.h[0], dg.h[1], dg.h[2], dg.h[3], dg.h[4], dg.h[5], dg.h[6], dg.h[7]
+
+		for i := 0; i < 80; i++ {
+			s1 := byteutil.Rrot64(e, 14) ^ byteutil.Rrot64(e, 18) ^ byteutil.Rrot64(e, 41)
+			ch := (e & f) ^ (^e & g)
+			t1 := h + s1 + ch + sha512K[i] + w[i]
+
+			s0 := byteutil.Rrot64(a, 28) ^ byteutil.Rrot64(a, 34) ^ byteutil.Rrot64(a, 39)
+			maj := (a & b) ^ (a & c) ^ (b & c)
+			t2 := s0 + maj
+
+			h = g
+			g = f
+			f = e
+			e = d + t1
+			d = c
+			c = b
+			b = a
+			a = t1 + t2
+		}
+
+		dg.h[0] += a
+		dg.h[1] += b
+		dg.h[2] += c
+		dg.h[3] += d
+		dg.h[4] += e
+		dg.h[5] += f
+		dg.h[6] += g
+		dg.h[7] += h
+
+		p = p[SHA512BlockSize:]
+	}
+}
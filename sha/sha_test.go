@@ -0,0 +1,88 @@
+package sha
+
+import (
+	"bytes"
+	"encoding/hex"
+	"strings"
+	"testing"
+)
+
+func TestSHA(t *testing.T) {
+	var messages = map[string]string{
+		"A": "6dcd4ce23d88e2ee9568ba546c007c63d9131c1b",
+		"The quick brown fox jumps over the lazy dog": "2fd4e1c67a2d28fced849ee1bb76e7391b93eb12",
+	}
+
+	dmsg := make([]byte, bytes.MinRead)
+
+	sha := SHA1{}
+	for message, dig := range messages {
+		reader := strings.NewReader(message)
+
+		read, err := reader.Read(dmsg)
+		if read > 0 && err == nil {
+			computedHex := hex.EncodeToString(sha.Digest(dmsg[:read]))
+			if computedHex != dig {
+				t.Errorf("Incorrect digest SHA1(\"%s\") should be \"%s\" but was \"%s\"", message, dig, computedHex)
+			}
+
+			read, err = reader.Read(dmsg)
+		} else {
+			t.Error("Could not read data", err)
+		}
+	}
+}
+
+// NIST SHA-1 short and long message vectors
+var sha1Vectors = []struct {
+	message, digest string
+}{
+	{"", "da39a3ee5e6b4b0d3255bfef95601890afd80709"},
+	{"abc", "a9993e364706816aba3e25717850c26c9cd0d89d"},
+	{"abcdbcdecdefdefgefghfghighijhijkijkljklmklmnlmnomnopnopq", "84983e441c3bd26ebaae4aa1f95129e5e54670f1"},
+	{"abcdefghbcdefghicdefghijdefghijkefghijklfghijklmghijklmnhijklmnoijklmnopjklmnopqklmnopqrlmnopqrsmnopqrstnopqrstu", "a49b2446a02c645bf419f995b67091253a04a259"},
+	{strings.Repeat("a", 1000000), "34aa973cd4c4daa4f61eeb2bdbad27316534016f"},
+}
+
+func TestSHA1Vectors(t *testing.T) {
+	sha := SHA1{}
+	for _, v := range sha1Vectors {
+		computedHex := hex.EncodeToString(sha.Digest([]byte(v.message)))
+		if computedHex != v.digest {
+			t.Errorf("Incorrect digest of a %d byte message, expected %s got %s", len(v.message), v.digest, computedHex)
+		}
+	}
+}
+
+func TestSHA1Streaming(t *testing.T) {
+	for _, v := range sha1Vectors {
+		d := NewSHA1()
+
+		// Write the message in uneven pieces
+		for i := 0; i < len(v.message); i += 13 {
+			end := i + 13
+			if end > len(v.message) {
+				end = len(v.message)
+			}
+			d.Write([]byte(v.message[i:end]))
+		}
+
+		if computedHex := hex.EncodeToString(d.Sum(nil)); computedHex != v.digest {
+			t.Errorf("Incorrect streaming digest of a %d byte message, expected %s got %s", len(v.message), v.digest, computedHex)
+		}
+
+		// Sum must not change the state
+		if computedHex := hex.EncodeToString(d.Sum([]byte{})); computedHex != v.digest {
+			t.Errorf("Sum changed the state of the hash for a %d byte message", len(v.message))
+		}
+
+		d.Reset()
+		if computedHex := hex.EncodeToString(d.Sum(nil)); computedHex != sha1Vectors[0].digest {
+			t.Errorf("Incorrect digest after Reset, got %s", computedHex)
+		}
+	}
+
+	if d := NewSHA1(); d.Size() != SHA1Size || d.BlockSize() != SHA1BlockSize {
+		t.Errorf("Incorrect sizes %d, %d", d.Size(), d.BlockSize())
+	}
+}